@@ -0,0 +1,69 @@
+package fuzzy
+
+/*
+matchAcronym reports whether the runes of t are exactly the initials of the
+words of s, such as "gfs" for "GetFileSize", "get_file_size" or "get-file-size".
+If they are, it returns the score of matching the initials including
+AcronymMatchBonus along with indexes extended by the byte index of every
+initial. indexes must be empty. Patterns of a single rune are never acronyms.
+*/
+func matchAcronym(s string, t *term, indexes []int, opts *Options, sc *scratch) (int, []int, bool) {
+	runes := t.runes
+	if len(runes) < 2 {
+		return 0, indexes, false
+	}
+	// Most strings aren't acronyms of the pattern, so check before preparing s.
+	var i int
+	// initial reports whether r is either no word start or the next rune of the pattern.
+	initial := func(last, r, next rune) bool {
+		if !isWordStart(last, r, next, opts) {
+			return true
+		}
+		if i == len(runes) || !t.equal(runes[i], r) {
+			return false
+		}
+		i++
+		return true
+	}
+	// Every rune is looked at once the rune after it is known.
+	var last, r rune
+	for j, next := range s {
+		if j > 0 && !initial(last, r, next) {
+			return 0, indexes, false
+		}
+		last, r = r, next
+	}
+	if s == "" || !initial(last, r, 0) || i != len(runes) {
+		return 0, indexes, false
+	}
+	sc.prepare(s, opts)
+	sc.positions = sc.positions[:0]
+	last = 0
+	for j, r := range sc.runes {
+		var next rune
+		if j+1 < len(sc.runes) {
+			next = sc.runes[j+1]
+		}
+		if isWordStart(last, r, next, opts) {
+			sc.positions = append(sc.positions, j)
+			indexes = append(indexes, sc.offsets[j])
+		}
+		last = r
+	}
+	sc.recordIndexes(indexes, nil, opts)
+	sc.record(eventAcronym, -1, opts.AcronymMatchBonus)
+	return sc.score(sc.positions, nil, opts) + opts.AcronymMatchBonus, indexes, true
+}
+
+// isWordStart reports whether r starts a word when it follows last and is followed by next. Both
+// are 0 at the ends of the string. Words start after a separator and at the boundaries described for
+// isWordBoundary.
+func isWordStart(last, r, next rune, opts *Options) bool {
+	switch {
+	case opts.separators.contains(r):
+		return false
+	case last == 0 || opts.separators.contains(last):
+		return true
+	}
+	return isWordBoundary(last, r, next)
+}
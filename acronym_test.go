@@ -0,0 +1,84 @@
+package fuzzy_test
+
+import (
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestFindAcronym(t *testing.T) {
+	data := []string{"configs/gfs.yaml", "GetFileSize", "get_file_size", "getFileSizeAsync"}
+	// (g = 10, F = 20, S = 20) + 30 acronym - 8 unmatched chars = 72
+	want := fuzzy.Matches{
+		{
+			Str:            "GetFileSize",
+			Index:          1,
+			MatchedIndexes: []int{0, 3, 7},
+			Score:          72,
+		},
+		{
+			Str:            "get_file_size",
+			Index:          2,
+			MatchedIndexes: []int{0, 4, 9},
+			Score:          70,
+		},
+		{
+			Str:            "getFileSizeAsync",
+			Index:          3,
+			MatchedIndexes: []int{0, 3, 7},
+			Score:          37,
+		},
+		{
+			Str:            "configs/gfs.yaml",
+			Index:          0,
+			MatchedIndexes: []int{8, 9, 10},
+			Score:          12,
+		},
+	}
+	got := fuzzy.Find("gfs", data)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestCompileAcronym(t *testing.T) {
+	cases := []struct {
+		pattern string
+		str     string
+		acronym bool
+		indexes []int
+	}{
+		{"gfs", "GetFileSize", true, []int{0, 3, 7}},
+		{"gfs", "get-file-size", true, []int{0, 4, 9}},
+		{"gfs", "get file size", true, []int{0, 4, 9}},
+		{"gfs", "_get__file_size_", true, []int{1, 6, 11}},
+		{"v2b", "v2beta", true, []int{0, 1, 2}},
+		{"v2b", "v2_beta", true, []int{0, 1, 3}},
+		{"vb", "v2beta", false, []int{0, 2}},
+		{"hs", "HTTPServer", true, []int{0, 4}},
+		{"ab", "alpha_bravo.go", false, []int{0, 6}},
+		{"gs", "GetFileSize", false, []int{0, 7}},
+		{"g", "Get", false, []int{0}},
+		{"gfs", "GFS", false, []int{0, 1, 2}},
+		// the case of the pattern doesn't matter unless case sensitive
+		{"GFS", "get_file_size", true, []int{0, 4, 9}},
+	}
+	opts := fuzzy.DefaultOptions
+	opts.AcronymMatchBonus = 0
+	for _, c := range cases {
+		without, _ := fuzzy.Compile(c.pattern, fuzzy.WithOptions(opts)).Match(c.str)
+		with, ok := fuzzy.Compile(c.pattern).Match(c.str)
+		if !ok {
+			t.Errorf("%q didn't match %q", c.pattern, c.str)
+			continue
+		}
+		if acronym := with.Score-without.Score == fuzzy.DefaultOptions.AcronymMatchBonus; acronym != c.acronym {
+			t.Errorf("%q in %q: got acronym %v; expected %v", c.pattern, c.str, acronym, c.acronym)
+		}
+		if diff := pretty.Compare(c.indexes, with.MatchedIndexes); diff != "" {
+			t.Errorf("%q in %q: %v", c.pattern, c.str, diff)
+		}
+	}
+}
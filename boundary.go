@@ -0,0 +1,72 @@
+package fuzzy
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// scripts groups the scripts whose letters don't belong to the same word. Han, Hiragana and
+// Katakana are mixed within Japanese words, so they are considered a single script.
+var scripts = [][]*unicode.RangeTable{
+	{unicode.Latin},
+	{unicode.Greek},
+	{unicode.Cyrillic},
+	{unicode.Armenian},
+	{unicode.Georgian},
+	{unicode.Hebrew},
+	{unicode.Arabic},
+	{unicode.Devanagari},
+	{unicode.Thai},
+	{unicode.Hangul},
+	{unicode.Han, unicode.Hiragana, unicode.Katakana},
+}
+
+/*
+isWordBoundary reports whether r starts a new word although it directly follows
+prev, which is 0 at the start of a string. next is the rune following r, or 0 at
+the end of the string. Separators aren't considered. A word starts:
+
+* at an upper case letter following a lower case one, like the F of fileName.
+
+* at an upper case letter followed by a lower case one in a run of upper case
+letters, like the S of HTTPServer.
+
+* at a digit following a letter or a letter following a digit, like the 8 and
+the D of utf8Decode.
+
+* at a letter of a different script than the previous letter.
+*/
+func isWordBoundary(prev, r, next rune) bool {
+	switch {
+	case prev == 0:
+		return false
+	case unicode.IsUpper(r):
+		if unicode.IsLower(prev) {
+			return true
+		}
+		if unicode.IsUpper(prev) {
+			return unicode.IsLower(next)
+		}
+	}
+	if unicode.IsDigit(r) {
+		return unicode.IsLetter(prev)
+	}
+	if unicode.IsDigit(prev) {
+		return unicode.IsLetter(r)
+	}
+	// ASCII letters are all Latin.
+	if prev < utf8.RuneSelf && r < utf8.RuneSelf {
+		return false
+	}
+	return unicode.IsLetter(prev) && unicode.IsLetter(r) && script(prev) != script(r)
+}
+
+// script returns the index of the group of scripts of r in scripts, or -1 if it's in none of them.
+func script(r rune) int {
+	for i, tables := range scripts {
+		if unicode.In(r, tables...) {
+			return i
+		}
+	}
+	return -1
+}
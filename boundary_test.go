@@ -0,0 +1,53 @@
+package fuzzy_test
+
+import (
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+)
+
+func TestWordBoundaries(t *testing.T) {
+	cases := []struct {
+		str      string
+		pattern  string
+		boundary bool
+	}{
+		{"fileName", "N", true},
+		{"FileName", "N", true},
+		{"filename", "n", false},
+		{"FILENAME", "N", false},
+		{"HTTPServer", "S", true},
+		{"HTTPServer", "P", false},
+		{"HTTPS", "S", false},
+		{"getHTTPStatus", "H", true},
+		{"getHTTPStatus", "S", true},
+		{"utf8Decode", "8", true},
+		{"utf8Decode", "D", true},
+		{"utf8decode", "d", true},
+		{"v2Api", "2", true},
+		{"v2Api", "A", true},
+		{"x11", "1", true},
+		{"2024", "4", false},
+		{"helloМир", "М", true},
+		{"helloмир", "м", true},
+		{"straße", "ß", false},
+		{"東京タワー", "タ", false},
+		{"abcαβγ", "α", true},
+		{"αβγ", "β", false},
+	}
+	noBoundaries := fuzzy.DefaultOptions
+	noBoundaries.CamelCaseMatchBonus = 0
+	for _, algorithm := range []fuzzy.Algorithm{fuzzy.AlgorithmGreedy, fuzzy.AlgorithmOptimal} {
+		for _, c := range cases {
+			without, _ := fuzzy.Compile(c.pattern, fuzzy.WithOptions(noBoundaries), fuzzy.WithAlgorithm(algorithm), fuzzy.WithCaseMode(fuzzy.CaseSensitive)).Match(c.str)
+			with, ok := fuzzy.Compile(c.pattern, fuzzy.WithAlgorithm(algorithm), fuzzy.WithCaseMode(fuzzy.CaseSensitive)).Match(c.str)
+			if !ok {
+				t.Errorf("%q didn't match %q", c.pattern, c.str)
+				continue
+			}
+			if boundary := with.Score-without.Score == fuzzy.DefaultOptions.CamelCaseMatchBonus; boundary != c.boundary {
+				t.Errorf("%q in %q with algorithm %v: got word boundary %v; expected %v", c.pattern, c.str, algorithm, boundary, c.boundary)
+			}
+		}
+	}
+}
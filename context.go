@@ -0,0 +1,87 @@
+package fuzzy
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// contextCheckInterval is the number of strings matched between checks of the context.
+const contextCheckInterval = 256
+
+/*
+FindContext is an alternative implementation of Find that stops matching
+once ctx is done. It then returns the matches found so far, sorted, along
+with ctx.Err().
+*/
+func FindContext(ctx context.Context, pattern string, data []string) (Matches, error) {
+	return compile(pattern, DefaultOptions).FindContext(ctx, data)
+}
+
+/*
+FindFromContext is an alternative implementation of FindFrom that stops
+matching once ctx is done.
+*/
+func FindFromContext(ctx context.Context, pattern string, data Source) (Matches, error) {
+	return compile(pattern, DefaultOptions).FindFromContext(ctx, data)
+}
+
+/*
+FindFromIterContext is an alternative implementation of FindFromIter that
+stops matching once ctx is done.
+*/
+func FindFromIterContext(ctx context.Context, pattern string, it iter.Seq[string]) (Matches, error) {
+	return compile(pattern, DefaultOptions).FindFromIterContext(ctx, it)
+}
+
+// FindContext is an alternative implementation of Find that stops matching once ctx is done. It
+// then returns the matches found so far, sorted, along with ctx.Err().
+func (m *Matcher) FindContext(ctx context.Context, data []string) (Matches, error) {
+	return m.FindFromContext(ctx, stringSource(data))
+}
+
+// FindFromContext is an alternative implementation of FindFrom that stops matching once ctx is
+// done.
+func (m *Matcher) FindFromContext(ctx context.Context, data Source) (Matches, error) {
+	// With Options.Concurrency every goroutine checks ctx on its own.
+	var mu sync.Mutex
+	var err error
+	matches := m.findSharded(data, func(it iter.Seq[string], offset int) Matches {
+		matches, shardErr := m.findFromIterNoSortContext(ctx, it, offset)
+		if shardErr != nil {
+			mu.Lock()
+			err = shardErr
+			mu.Unlock()
+		}
+		return matches
+	})
+	m.sort(matches)
+	return matches, err
+}
+
+// FindFromIterContext is an alternative implementation of FindFromIter that stops matching once
+// ctx is done.
+func (m *Matcher) FindFromIterContext(ctx context.Context, it iter.Seq[string]) (Matches, error) {
+	matches, err := m.findFromIterNoSortContext(ctx, it, 0)
+	m.sort(matches)
+	return matches, err
+}
+
+func (m *Matcher) findFromIterNoSortContext(ctx context.Context, it iter.Seq[string], offset int) (Matches, error) {
+	var err error
+	matches := m.findFromIterNoSort(func(yield func(string) bool) {
+		var i int
+		for s := range it {
+			if i%contextCheckInterval == 0 {
+				if err = ctx.Err(); err != nil {
+					return
+				}
+			}
+			i++
+			if !yield(s) {
+				return
+			}
+		}
+	}, offset)
+	return matches, err
+}
@@ -0,0 +1,124 @@
+package fuzzy_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestFindContext(t *testing.T) {
+	data := []string{"moduleNameResolver.ts", "game.cpp", "my name is_Ramsey"}
+	got, err := fuzzy.FindContext(context.Background(), "mnr", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := pretty.Compare(fuzzy.Find("mnr", data), got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestFindContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err := fuzzy.FindContext(ctx, "mnr", []string{"moduleNameResolver.ts"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v; expected %v", err, context.Canceled)
+	}
+	if len(got) != 0 {
+		t.Errorf("got %v Matches; expected none", len(got))
+	}
+}
+
+func TestCompileFindContext(t *testing.T) {
+	bytes, err := os.ReadFile("testdata/linux_filenames.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filenames := strings.Split(string(bytes), "\n")
+	m := fuzzy.Compile("alsa", fuzzy.WithPathMode(), fuzzy.WithConcurrency(4))
+	got, err := m.FindContext(context.Background(), filenames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := pretty.Compare(m.Find(filenames), got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err = m.FindContext(ctx, filenames)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v; expected %v", err, context.Canceled)
+	}
+	if len(got) != 0 {
+		t.Errorf("got %v Matches; expected none", len(got))
+	}
+	if _, err := m.FindFromIterContext(ctx, slices.Values(filenames)); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v; expected %v", err, context.Canceled)
+	}
+}
+
+// cancellingSource cancels a context once the string at index at is read.
+type cancellingSource struct {
+	data   []string
+	at     int
+	cancel context.CancelFunc
+}
+
+func (c cancellingSource) String(i int) string {
+	if i == c.at {
+		c.cancel()
+	}
+	return c.data[i]
+}
+
+func (c cancellingSource) Len() int { return len(c.data) }
+
+func TestFindFromContextPartialResults(t *testing.T) {
+	bytes, err := os.ReadFile("testdata/linux_filenames.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filenames := strings.Split(string(bytes), "\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	const at = 10000
+	got, err := fuzzy.FindFromContext(ctx, "c", cancellingSource{data: filenames, at: at, cancel: cancel})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v; expected %v", err, context.Canceled)
+	}
+	if len(got) == 0 {
+		t.Fatal("got no Matches; expected the Matches found before cancellation")
+	}
+	// the context is checked periodically, so matching stops soon after it is cancelled
+	var before int
+	for _, m := range got {
+		if m.Index > at+256 {
+			t.Fatalf("got match at index %v; expected matching to stop soon after %v", m.Index, at)
+		}
+		if m.Index <= at {
+			before++
+		}
+	}
+	if want := fuzzy.Find("c", filenames[:at+1]); before != len(want) {
+		t.Errorf("got %v Matches before cancellation; expected %v", before, len(want))
+	}
+}
+
+func TestFindFromIterContext(t *testing.T) {
+	emps := employees{{name: "Alice"}, {name: "Bob"}, {name: "Allie"}}
+	got, err := fuzzy.FindFromIterContext(context.Background(), "al", emps.Values())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := pretty.Compare(fuzzy.FindFromIter("al", emps.Values()), got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
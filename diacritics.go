@@ -0,0 +1,63 @@
+package fuzzy
+
+//go:generate go run -C internal/gendiacritics . -o ../../diacritics_table.go
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// stripDiacritic returns r without diacritics. It reports false if r is a nonspacing combining
+// mark, which has no base rune and is dropped.
+func stripDiacritic(r rune) (rune, bool) {
+	if r < utf8.RuneSelf {
+		return r, true
+	}
+	if unicode.Is(unicode.Mn, r) {
+		return 0, false
+	}
+	if base, ok := baseRunes[r]; ok {
+		return base, true
+	}
+	return r, true
+}
+
+// stripDiacritics removes diacritics from runes in place.
+func stripDiacritics(runes []rune) []rune {
+	stripped := runes[:0]
+	for _, r := range runes {
+		if base, ok := stripDiacritic(r); ok {
+			stripped = append(stripped, base)
+		}
+	}
+	return stripped
+}
+
+// stripDiacritics returns s without diacritics along with the byte offset in s of every rune of
+// the result, indexed by its byte offset in the result. It returns s itself and no offsets if s
+// doesn't have diacritics.
+func (sc *scratch) stripDiacritics(s string) (string, []int) {
+	unchanged := true
+	for _, r := range s {
+		if base, ok := stripDiacritic(r); !ok || base != r {
+			unchanged = false
+			break
+		}
+	}
+	if unchanged {
+		return s, nil
+	}
+	sc.stripped = sc.stripped[:0]
+	sc.origin = sc.origin[:0]
+	for j, r := range s {
+		base, ok := stripDiacritic(r)
+		if !ok {
+			continue
+		}
+		for range utf8.RuneLen(base) {
+			sc.origin = append(sc.origin, j)
+		}
+		sc.stripped = utf8.AppendRune(sc.stripped, base)
+	}
+	return string(sc.stripped), sc.origin
+}
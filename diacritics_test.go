@@ -0,0 +1,76 @@
+package fuzzy_test
+
+import (
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestCompileWithIgnoreDiacritics(t *testing.T) {
+	cases := []struct {
+		pattern string
+		str     string
+		indexes []int
+	}{
+		// precomposed é
+		{"cafe", "caf\u00e9", []int{0, 1, 2, 3}},
+		// decomposed é, the combining mark isn't highlighted
+		{"cafe", "cafe\u0301", []int{0, 1, 2, 3}},
+		{"caf\u00e9", "cafe", []int{0, 1, 2, 3}},
+		{"cafe\u0301", "caf\u00e9", []int{0, 1, 2, 3}},
+		// indexes are byte offsets into the original string
+		{"angstrom", "Ångström", []int{0, 2, 3, 4, 5, 6, 7, 9}},
+		{"ca fe", "Ça fée", []int{0, 2, 3, 4, 5}},
+	}
+	for _, c := range cases {
+		for _, algorithm := range []fuzzy.Algorithm{fuzzy.AlgorithmGreedy, fuzzy.AlgorithmOptimal} {
+			m := fuzzy.Compile(c.pattern, fuzzy.WithIgnoreDiacritics(), fuzzy.WithAlgorithm(algorithm))
+			got, ok := m.Match(c.str)
+			if !ok {
+				t.Errorf("%q didn't match %q", c.pattern, c.str)
+				continue
+			}
+			if diff := pretty.Compare(c.indexes, got.MatchedIndexes); diff != "" {
+				t.Errorf("%q in %q: %v", c.pattern, c.str, diff)
+			}
+		}
+	}
+}
+
+func TestCompileWithoutIgnoreDiacritics(t *testing.T) {
+	if _, ok := fuzzy.MatchOne("cafe", "caf\u00e9"); ok {
+		t.Error("got a match; expected diacritics not to be ignored by default")
+	}
+}
+
+func TestCompileWithIgnoreDiacriticsScoresLikeStrippedString(t *testing.T) {
+	m := fuzzy.Compile("cafe", fuzzy.WithIgnoreDiacritics())
+	want, _ := m.Match("cafe")
+	for _, s := range []string{"caf\u00e9", "cafe\u0301"} {
+		got, ok := m.Match(s)
+		if !ok || got.Score != want.Score {
+			t.Errorf("got score %v for %q; expected %v", got.Score, s, want.Score)
+		}
+	}
+}
+
+func TestParseQueryWithIgnoreDiacritics(t *testing.T) {
+	got := fuzzy.ParseQuery("^ca fé$ !naïve", fuzzy.WithIgnoreDiacritics()).Find([]string{
+		"Ça fe",
+		"Ça fe naive",
+		"ca fee",
+	})
+	want := fuzzy.Matches{
+		{
+			Str:            "Ça fe",
+			Index:          0,
+			MatchedIndexes: []int{0, 2, 4, 5},
+			Score:          24,
+		},
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
@@ -0,0 +1,238 @@
+package fuzzy
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Explanation itemizes the score of a match. The contributions of its runes and all other fields
+// add up to Score.
+type Explanation struct {
+	Match
+	// The contributions of every matched rune in order of their index. A rune matched by several
+	// terms is listed once for every term.
+	Runes []RuneScore
+	// The penalty for the characters before the first matched rune of every term.
+	LeadingPenalty int
+	// The penalty for the characters that weren't matched.
+	UnmatchedPenalty int
+	// The bonus for patterns made of the initials of the words in the string.
+	AcronymBonus int
+	// The penalty for typos.
+	TypoPenalty int
+	// The bonus for pattern segments matched in adjacent path segments.
+	AdjacentSegmentBonus int
+	// The bonuses and penalties of path mode.
+	BasenameBonus     int
+	FullBasenameBonus int
+	PathDepthPenalty  int
+	// The boost of Options.Booster.
+	Boost int
+}
+
+// RuneScore holds the bonuses of a matched rune.
+type RuneScore struct {
+	// The byte index of the rune in Str.
+	Index int
+	Rune  rune
+	// The bonuses described for the Options fields named after them.
+	FirstChar               int
+	CamelCase               int
+	MatchFollowingSeparator int
+	Adjacent                int
+}
+
+// Score returns the sum of the bonuses of r.
+func (r RuneScore) Score() int {
+	return r.FirstChar + r.CamelCase + r.MatchFollowingSeparator + r.Adjacent
+}
+
+/*
+Explain matches a single string against pattern like MatchOne and itemizes the
+score of the match. It is meant for tuning Options and reporting surprising
+rankings, and is considerably slower than MatchOne.
+*/
+func Explain(pattern, s string) (Explanation, bool) {
+	return compile(pattern, DefaultOptions).Explain(s)
+}
+
+// Explain matches s against the pattern like Match and itemizes the score of the match.
+func (m *Matcher) Explain(s string) (Explanation, bool) {
+	if m.empty() {
+		return Explanation{}, false
+	}
+	sc := scratch{explaining: true}
+	score, indexes, ok := m.match(s, make([]int, 0, m.indexCap), &sc)
+	if !ok {
+		return Explanation{}, false
+	}
+	e := Explanation{Match: Match{Str: s, MatchedIndexes: indexes, Score: score}}
+	for _, ev := range sc.events {
+		var r *RuneScore
+		if len(e.Runes) > 0 {
+			r = &e.Runes[len(e.Runes)-1]
+		}
+		switch ev.kind {
+		case eventRune:
+			c, _ := utf8.DecodeRuneInString(s[ev.index:])
+			e.Runes = append(e.Runes, RuneScore{Index: ev.index, Rune: c})
+		case eventFirstChar:
+			r.FirstChar += ev.points
+		case eventCamelCase:
+			r.CamelCase += ev.points
+		case eventSeparator:
+			r.MatchFollowingSeparator += ev.points
+		case eventAdjacent:
+			r.Adjacent += ev.points
+		case eventLeading:
+			e.LeadingPenalty += ev.points
+		case eventUnmatched:
+			e.UnmatchedPenalty += ev.points
+		case eventAcronym:
+			e.AcronymBonus += ev.points
+		case eventTypos:
+			e.TypoPenalty += ev.points
+		case eventSegment:
+			e.AdjacentSegmentBonus += ev.points
+		case eventBasename:
+			e.BasenameBonus += ev.points
+		case eventFullBasename:
+			e.FullBasenameBonus += ev.points
+		case eventPathDepth:
+			e.PathDepthPenalty += ev.points
+		case eventBoost:
+			e.Boost += ev.points
+		}
+	}
+	slices.SortStableFunc(e.Runes, func(a, b RuneScore) int { return a.Index - b.Index })
+	return e, true
+}
+
+// String formats e as a table of its runes followed by the remaining contributions that aren't 0.
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q scores %d\n", e.Str, e.Score)
+	fmt.Fprintf(&b, "%6s %6s %10s %10s %10s %10s\n", "index", "rune", "first", "camel", "separator", "adjacent")
+	for _, r := range e.Runes {
+		fmt.Fprintf(&b, "%6d %6q %10d %10d %10d %10d\n", r.Index, r.Rune, r.FirstChar, r.CamelCase, r.MatchFollowingSeparator, r.Adjacent)
+	}
+	for _, c := range []struct {
+		name   string
+		points int
+	}{
+		{"leading penalty", e.LeadingPenalty},
+		{"unmatched penalty", e.UnmatchedPenalty},
+		{"acronym bonus", e.AcronymBonus},
+		{"typo penalty", e.TypoPenalty},
+		{"adjacent segment bonus", e.AdjacentSegmentBonus},
+		{"basename bonus", e.BasenameBonus},
+		{"full basename bonus", e.FullBasenameBonus},
+		{"path depth penalty", e.PathDepthPenalty},
+		{"boost", e.Boost},
+	} {
+		if c.points != 0 {
+			fmt.Fprintf(&b, "%s %d\n", c.name, c.points)
+		}
+	}
+	return b.String()
+}
+
+// eventKind identifies a contribution to a score.
+type eventKind int
+
+const (
+	// eventRune starts the contributions of a matched rune, which follow it.
+	eventRune eventKind = iota
+	eventFirstChar
+	eventCamelCase
+	eventSeparator
+	eventAdjacent
+	eventLeading
+	eventUnmatched
+	eventAcronym
+	eventTypos
+	eventSegment
+	eventBasename
+	eventFullBasename
+	eventPathDepth
+	eventBoost
+)
+
+// event is a contribution to the score of the string being explained.
+type event struct {
+	kind eventKind
+	// The byte index of the matched rune for eventRune, or -1.
+	index  int
+	points int
+}
+
+// record adds a contribution to the score if the string is being explained. Contributions of 0
+// are left out unless they start a rune.
+func (sc *scratch) record(kind eventKind, index, points int) {
+	if sc.explaining && (points != 0 || kind == eventRune) {
+		sc.events = append(sc.events, event{kind: kind, index: index, points: points})
+	}
+}
+
+// recordRune records the bonuses of a rune matched at byte index j.
+func (sc *scratch) recordRune(j, first, camel, separator, adjacent int) {
+	sc.record(eventRune, j, 0)
+	sc.record(eventFirstChar, -1, first)
+	sc.record(eventCamelCase, -1, camel)
+	sc.record(eventSeparator, -1, separator)
+	sc.record(eventAdjacent, -1, adjacent)
+}
+
+// recordIndexes records the contributions of matching the prepared string at indexes with the
+// given breaks like scratch.score does.
+func (sc *scratch) recordIndexes(indexes []int, breaks []bool, opts *Options) {
+	if !sc.explaining || len(indexes) == 0 {
+		return
+	}
+	var adjacent, last int
+	for i, index := range indexes {
+		j, _ := slices.BinarySearch(sc.offsets, index)
+		if i == 0 {
+			sc.record(eventLeading, -1, sc.leadingPenalty(j, opts))
+		}
+		var prev, next rune
+		if j > 0 {
+			prev = sc.runes[j-1]
+		}
+		if j+1 < len(sc.runes) {
+			next = sc.runes[j+1]
+		}
+		var first, camel, separator, bonus int
+		if j == 0 {
+			first = opts.FirstCharMatchBonus
+		}
+		if isWordBoundary(prev, sc.runes[j], next) {
+			camel = opts.CamelCaseMatchBonus
+		}
+		if j != 0 && opts.separators.contains(prev) {
+			separator = opts.MatchFollowingSeparatorBonus
+		}
+		if i > 0 && last == j-1 && (breaks == nil || !breaks[i]) {
+			adjacent++
+			bonus = adjacentBonusTotal(adjacent, opts) - adjacentBonusTotal(adjacent-1, opts)
+		}
+		sc.recordRune(index, first, camel, separator, bonus)
+		last = j
+	}
+}
+
+// discardEvents removes the contributions recorded between lo and hi.
+func (sc *scratch) discardEvents(lo, hi int) {
+	sc.events = slices.Delete(sc.events, lo, hi)
+}
+
+// shiftEvents adds offset to the byte index of the runes recorded since lo.
+func (sc *scratch) shiftEvents(lo, offset int) {
+	for i := lo; i < len(sc.events); i++ {
+		if sc.events[i].index >= 0 {
+			sc.events[i].index += offset
+		}
+	}
+}
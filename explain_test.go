@@ -0,0 +1,108 @@
+package fuzzy_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestExplain(t *testing.T) {
+	want := fuzzy.Explanation{
+		Match: fuzzy.Match{
+			Str:            "GetFileSize",
+			MatchedIndexes: []int{0, 3, 7},
+			Score:          72,
+		},
+		Runes: []fuzzy.RuneScore{
+			{Index: 0, Rune: 'G', FirstChar: 10},
+			{Index: 3, Rune: 'F', CamelCase: 20},
+			{Index: 7, Rune: 'S', CamelCase: 20},
+		},
+		UnmatchedPenalty: -8,
+		AcronymBonus:     30,
+	}
+	got, ok := fuzzy.Explain("gfs", "GetFileSize")
+	if !ok {
+		t.Fatal("gfs didn't match GetFileSize")
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+	wantString := `"GetFileSize" scores 72
+ index   rune      first      camel  separator   adjacent
+     0    'G'         10          0          0          0
+     3    'F'          0         20          0          0
+     7    'S'          0         20          0          0
+unmatched penalty -8
+acronym bonus 30
+`
+	if diff := pretty.Compare(wantString, got.String()); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestExplainNoMatch(t *testing.T) {
+	if e, ok := fuzzy.Explain("cats", "cat"); ok {
+		t.Errorf("got %v; expected no match", e)
+	}
+}
+
+func TestExplainAddsUpToScore(t *testing.T) {
+	bytes, err := os.ReadFile("testdata/ue4_filenames.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filenames := strings.Split(string(bytes), "\n")
+	paths := make([]string, len(filenames))
+	for i, filename := range filenames {
+		paths[i] = "Engine/Source/Runtime/" + filename
+	}
+	cases := []struct {
+		query string
+		data  []string
+		opts  []fuzzy.Option
+	}{
+		{"ue4", filenames, nil},
+		{"lll", filenames, nil},
+		{"gfs", filenames, nil},
+		// the greedy algorithm grows the adjacency bonus of c for the b it didn't match
+		{"abc", []string{"ab_bc"}, nil},
+		{"abc", filenames, []fuzzy.Option{fuzzy.WithAlgorithm(fuzzy.AlgorithmOptimal)}},
+		{"mode ^game .h$ | .cpp$ !test", filenames, nil},
+		{"actr", filenames, []fuzzy.Option{fuzzy.WithMaxTypos(1)}},
+		{"hnadler", []string{"handler.go", "hander.go", "hXndler.go"}, []fuzzy.Option{fuzzy.WithMaxTypos(1)}},
+		{"sound", paths, []fuzzy.Option{fuzzy.WithPathMode()}},
+		{"e/s/r/aes", paths, []fuzzy.Option{fuzzy.WithPathSegments(), fuzzy.WithPathMode()}},
+		{"e/sxurce/aes", paths, []fuzzy.Option{fuzzy.WithPathSegments(), fuzzy.WithMaxTypos(1)}},
+		{"cafe", []string{"Café.cpp", "CAFE_Café.h"}, []fuzzy.Option{fuzzy.WithIgnoreDiacritics()}},
+	}
+	for _, c := range cases {
+		m := fuzzy.ParseQuery(c.query, c.opts...)
+		matches := m.Find(c.data)
+		if len(matches) == 0 {
+			t.Errorf("%q didn't match anything", c.query)
+		}
+		for _, match := range matches {
+			e, ok := m.Explain(match.Str)
+			if !ok {
+				t.Errorf("%q in %q: no explanation", c.query, match.Str)
+				continue
+			}
+			if diff := pretty.Compare(match.MatchedIndexes, e.MatchedIndexes); diff != "" {
+				t.Errorf("%q in %q: %v", c.query, match.Str, diff)
+			}
+			sum := e.LeadingPenalty + e.UnmatchedPenalty + e.AcronymBonus + e.TypoPenalty + e.AdjacentSegmentBonus +
+				e.BasenameBonus + e.FullBasenameBonus + e.PathDepthPenalty + e.Boost
+			for _, r := range e.Runes {
+				sum += r.Score()
+			}
+			if sum != match.Score || e.Score != match.Score {
+				t.Errorf("%q in %q: explanation adds up to %v; expected %v\n%v", c.query, match.Str, sum, match.Score, e)
+			}
+		}
+	}
+}
@@ -7,8 +7,7 @@ package fuzzy
 
 import (
 	"iter"
-	"sort"
-	"strings"
+	"slices"
 	"unicode"
 	"unicode/utf8"
 )
@@ -25,17 +24,6 @@ type Match struct {
 	Score int
 }
 
-const (
-	firstCharMatchBonus            = 10
-	matchFollowingSeparatorBonus   = 20
-	camelCaseMatchBonus            = 20
-	adjacentMatchBonus             = 5
-	unmatchedLeadingCharPenalty    = -5
-	maxUnmatchedLeadingCharPenalty = -15
-)
-
-var separators = []rune("/-_ .\\")
-
 // Matches is a slice of Match structs
 type Matches []Match
 
@@ -43,6 +31,13 @@ func (a Matches) Len() int           { return len(a) }
 func (a Matches) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a Matches) Less(i, j int) bool { return a[i].Score > a[j].Score }
 
+// SortFunc sorts the matches in the order determined by cmp, which returns a negative number if a
+// ranks before b and a positive number if it ranks after b. Matches it considers equal keep their
+// order.
+func (a Matches) SortFunc(cmp func(a, b Match) int) {
+	slices.SortStableFunc(a, cmp)
+}
+
 // Source represents an abstract source of a list of strings. Source must be iterable type such as a slice.
 // The source will be iterated over till Len() with String(i) being called for each element where i is the
 // index of the element. You can find a working example in the README.
@@ -62,8 +57,12 @@ func (ss stringSource) String(i int) string {
 func (ss stringSource) Len() int { return len(ss) }
 
 func iterFromSource(s Source) iter.Seq[string] {
+	return iterFromRange(s, 0, s.Len())
+}
+
+func iterFromRange(s Source, lo, hi int) iter.Seq[string] {
 	return func(yield func(string) bool) {
-		for i := 0; i < s.Len(); i++ {
+		for i := lo; i < hi; i++ {
 			if !yield(s.String(i)) {
 				return
 			}
@@ -80,12 +79,16 @@ The following types of matches apply a bonus:
 
 * The first character in the pattern matches the first character in the match string.
 
-* The matched character is camel cased.
+* The matched character is camel cased or otherwise starts a word, like the S of
+HTTPServer or the 8 of utf8.
 
 * The matched character follows a separator such as an underscore character.
 
 * The matched character is adjacent to a previous match.
 
+* The pattern is made of exactly the initials of the words in the match string,
+such as "gfs" for "GetFileSize".
+
 Penalties are applied for every character in the search string that wasn't matched and all leading
 characters upto the first match.
 
@@ -108,9 +111,23 @@ FindFrom is an alternative implementation of Find using a Source
 instead of a list of strings.
 */
 func FindFrom(pattern string, data Source) Matches {
-	matches := FindFromNoSort(pattern, data)
-	sort.Stable(matches)
-	return matches
+	return compile(pattern, DefaultOptions).FindFrom(data)
+}
+
+/*
+FindWithOptions is an alternative implementation of Find that scores
+matches using the weights in opts instead of DefaultOptions.
+*/
+func FindWithOptions(pattern string, data []string, opts Options) Matches {
+	return FindFromWithOptions(pattern, stringSource(data), opts)
+}
+
+/*
+FindFromWithOptions is an alternative implementation of FindFrom that scores
+matches using the weights in opts instead of DefaultOptions.
+*/
+func FindFromWithOptions(pattern string, data Source, opts Options) Matches {
+	return compile(pattern, opts).FindFrom(data)
 }
 
 /*
@@ -126,9 +143,7 @@ FindFromIter is an alternative implementation of FindFrom that uses an iterator
 instead of Source.
 */
 func FindFromIter(pattern string, it iter.Seq[string]) Matches {
-	matches := FindFromIterNoSort(pattern, it)
-	sort.Stable(matches)
-	return matches
+	return compile(pattern, DefaultOptions).FindFromIter(it)
 }
 
 /*
@@ -136,111 +151,117 @@ FindFromIterNoSort is an alternative implementation of FindFromIter that does
 not sort results in the end.
 */
 func FindFromIterNoSort(pattern string, it iter.Seq[string]) Matches {
-	if len(pattern) == 0 {
-		return nil
-	}
-	runes := []rune(pattern)
-	var matches Matches
-	var matchedIndexes []int
-	var i int
-	for matchStr := range it {
-		var match Match
-		match.Str = matchStr
-		// Limit matching to the first NUL rune, if any. We could maybe replace it
-		// with whitespace, but this way doesn't allocate so much, and the presence
-		// of NULs is most often an error by the library user.
-		cleanMatchStr := matchStr
-		if nullI := strings.IndexRune(matchStr, 0); nullI > -1 {
-			cleanMatchStr = cleanMatchStr[:nullI]
-		}
-		match.Index = i
-		i++
-		if matchedIndexes != nil {
-			match.MatchedIndexes = matchedIndexes
+	return compile(pattern, DefaultOptions).findFromIterNoSort(it, 0)
+}
+
+/*
+MatchSeq lazily matches pattern against the strings of it. Every match is
+yielded as soon as it is found, so matches are in input order rather than
+sorted. Match.Index is the position of the string in it.
+*/
+func MatchSeq(pattern string, it iter.Seq[string]) iter.Seq[Match] {
+	return compile(pattern, DefaultOptions).all(it, 0)
+}
+
+/*
+MatchOne matches a single string against pattern using the same rules as Find.
+It reports false if s doesn't match. The returned Match has an Index of 0.
+*/
+func MatchOne(pattern, s string) (Match, bool) {
+	return compile(pattern, DefaultOptions).Match(s)
+}
+
+/*
+Score is a cheaper alternative to MatchOne that only returns the score
+of s and doesn't allocate MatchedIndexes.
+*/
+func Score(pattern, s string) (int, bool) {
+	return compile(pattern, DefaultOptions).Score(s)
+}
+
+// matchGreedy matches the runes of t against s in a single forward pass and returns the score
+// along with indexes extended by the byte index of every matched rune. indexes must be empty. Not
+// every rune of t was found if fewer than len(t.runes) indexes were added.
+func matchGreedy(s string, t *term, indexes []int, opts *Options, sc *scratch) (int, []int) {
+	runes := t.runes
+	// The bonuses of the best candidate, which are only needed to explain the score.
+	var first, camel, separator, adjacent int
+	var best [4]int
+	var matchScore int
+	var score int
+	patternIndex := 0
+	bestScore := -1
+	matchedIndex := -1
+	currAdjacentMatchBonus := 0
+	var last rune
+	var lastIndex int
+	nextc, nextSize := utf8.DecodeRuneInString(s)
+	var candidate rune
+	var candidateSize int
+	for j := 0; j < len(s); j += candidateSize {
+		candidate, candidateSize = nextc, nextSize
+		if j+candidateSize < len(s) {
+			if s[j+candidateSize] < utf8.RuneSelf { // Fast path for ASCII
+				nextc, nextSize = rune(s[j+candidateSize]), 1
+			} else {
+				nextc, nextSize = utf8.DecodeRuneInString(s[j+candidateSize:])
+			}
 		} else {
-			match.MatchedIndexes = make([]int, 0, len(runes))
+			nextc, nextSize = 0, 0
 		}
-		var score int
-		patternIndex := 0
-		bestScore := -1
-		matchedIndex := -1
-		currAdjacentMatchBonus := 0
-		var last rune
-		var lastIndex int
-		nextc, nextSize := utf8.DecodeRuneInString(cleanMatchStr)
-		var candidate rune
-		var candidateSize int
-		for j := 0; j < len(cleanMatchStr); j += candidateSize {
-			candidate, candidateSize = nextc, nextSize
-			if equalFold(candidate, runes[patternIndex]) {
-				score = 0
-				if j == 0 {
-					score += firstCharMatchBonus
-				}
-				if unicode.IsLower(last) && unicode.IsUpper(candidate) {
-					score += camelCaseMatchBonus
-				}
-				if j != 0 && isSeparator(last) {
-					score += matchFollowingSeparatorBonus
-				}
-				if len(match.MatchedIndexes) > 0 {
-					lastMatch := match.MatchedIndexes[len(match.MatchedIndexes)-1]
-					bonus := adjacentCharBonus(lastIndex, lastMatch, currAdjacentMatchBonus)
-					score += bonus
-					// adjacent matches are incremental and keep increasing based on previous adjacent matches
-					// thus we need to maintain the current match bonus
-					currAdjacentMatchBonus += bonus
-				}
-				if score > bestScore {
-					bestScore = score
-					matchedIndex = j
-				}
+		if t.equal(runes[patternIndex], candidate) {
+			first, camel, separator, adjacent = 0, 0, 0, 0
+			if j == 0 {
+				first = opts.FirstCharMatchBonus
 			}
-			var nextp rune
-			if patternIndex < len(runes)-1 {
-				nextp = runes[patternIndex+1]
+			if isWordBoundary(last, candidate, nextc) {
+				camel = opts.CamelCaseMatchBonus
 			}
-			if j+candidateSize < len(cleanMatchStr) {
-				if cleanMatchStr[j+candidateSize] < utf8.RuneSelf { // Fast path for ASCII
-					nextc, nextSize = rune(cleanMatchStr[j+candidateSize]), 1
-				} else {
-					nextc, nextSize = utf8.DecodeRuneInString(cleanMatchStr[j+candidateSize:])
-				}
-			} else {
-				nextc, nextSize = 0, 0
+			if j != 0 && opts.separators.contains(last) {
+				separator = opts.MatchFollowingSeparatorBonus
 			}
-			// We apply the best score when we have the next match coming up or when the search string has ended.
-			// Tracking when the next match is coming up allows us to exhaustively find the best match and not necessarily
-			// the first match.
-			// For example given the pattern "tk" and search string "The Black Knight", exhaustively matching allows us
-			// to match the second k thus giving this string a higher score.
-			if equalFold(nextp, nextc) || nextc == 0 {
-				if matchedIndex > -1 {
-					if len(match.MatchedIndexes) == 0 {
-						penalty := matchedIndex * unmatchedLeadingCharPenalty
-						bestScore += max(penalty, maxUnmatchedLeadingCharPenalty)
-					}
-					match.Score += bestScore
-					match.MatchedIndexes = append(match.MatchedIndexes, matchedIndex)
-					score = 0
-					bestScore = -1
-					patternIndex++
-				}
+			if len(indexes) > 0 {
+				lastMatch := indexes[len(indexes)-1]
+				adjacent = adjacentCharBonus(lastIndex, lastMatch, currAdjacentMatchBonus, opts.AdjacentMatchBonus)
+				// adjacent matches are incremental and keep increasing based on previous adjacent matches
+				// thus we need to maintain the current match bonus
+				currAdjacentMatchBonus += adjacent
+			}
+			score = first + camel + separator + adjacent
+			if score > bestScore {
+				bestScore = score
+				matchedIndex = j
+				best = [4]int{first, camel, separator, adjacent}
 			}
-			lastIndex = j
-			last = candidate
 		}
-		// apply penalty for each unmatched character
-		penalty := len(match.MatchedIndexes) - len(cleanMatchStr)
-		match.Score += penalty
-		if len(match.MatchedIndexes) == len(runes) {
-			matches = append(matches, match)
-			matchedIndexes = nil
-		} else {
-			matchedIndexes = match.MatchedIndexes[:0] // Recycle match index slice
+		var nextp rune
+		if patternIndex < len(runes)-1 {
+			nextp = runes[patternIndex+1]
 		}
+		// We apply the best score when we have the next match coming up or when the search string has ended.
+		// Tracking when the next match is coming up allows us to exhaustively find the best match and not necessarily
+		// the first match.
+		// For example given the pattern "tk" and search string "The Black Knight", exhaustively matching allows us
+		// to match the second k thus giving this string a higher score.
+		if t.equal(nextp, nextc) || nextc == 0 {
+			if matchedIndex > -1 {
+				if len(indexes) == 0 {
+					penalty := matchedIndex * opts.UnmatchedLeadingCharPenalty
+					bestScore += max(penalty, opts.MaxUnmatchedLeadingCharPenalty)
+					sc.record(eventLeading, -1, max(penalty, opts.MaxUnmatchedLeadingCharPenalty))
+				}
+				sc.recordRune(matchedIndex, best[0], best[1], best[2], best[3])
+				matchScore += bestScore
+				indexes = append(indexes, matchedIndex)
+				score = 0
+				bestScore = -1
+				patternIndex++
+			}
+		}
+		lastIndex = j
+		last = candidate
 	}
-	return matches
+	return matchScore, indexes
 }
 
 // Taken from strings.EqualFold
@@ -269,22 +290,13 @@ func equalFold(tr, sr rune) bool {
 	return r == tr
 }
 
-func adjacentCharBonus(i int, lastMatch int, currentBonus int) int {
+func adjacentCharBonus(i int, lastMatch int, currentBonus int, adjacentMatchBonus int) int {
 	if lastMatch == i {
 		return currentBonus*2 + adjacentMatchBonus
 	}
 	return 0
 }
 
-func isSeparator(s rune) bool {
-	for _, sep := range separators {
-		if s == sep {
-			return true
-		}
-	}
-	return false
-}
-
 func max(x int, y int) int {
 	if x > y {
 		return x
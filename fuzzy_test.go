@@ -131,6 +131,53 @@ func TestFindWithCannedData(t *testing.T) {
 	}
 }
 
+func TestMatchOne(t *testing.T) {
+	got, ok := fuzzy.MatchOne("tk", "The Black Knight")
+	if !ok {
+		t.Fatal("got no match; expected a match")
+	}
+	want := fuzzy.Match{
+		Str:            "The Black Knight",
+		Index:          0,
+		MatchedIndexes: []int{0, 10},
+		Score:          16,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+	if _, ok := fuzzy.MatchOne("cats", "cat"); ok {
+		t.Error("got a match for cats in cat; expected none")
+	}
+}
+
+func TestScore(t *testing.T) {
+	cases := []struct {
+		pattern string
+		str     string
+		score   int
+		ok      bool
+	}{
+		{"mnr", "moduleNameResolver.ts", 32, true},
+		{"mnr", "my name is_Ramsey", 36, true},
+		{"ab", "alphabet\x00\x00\x00\x00bet", 4, true},
+		{"cats", "cat", 0, false},
+		{"", "cat", 0, false},
+	}
+	for _, c := range cases {
+		score, ok := fuzzy.Score(c.pattern, c.str)
+		if score != c.score || ok != c.ok {
+			t.Errorf("Score(%q, %q) = %v, %v; expected %v, %v", c.pattern, c.str, score, ok, c.score, c.ok)
+		}
+	}
+	m := fuzzy.Compile("mnr")
+	allocs := testing.AllocsPerRun(100, func() {
+		m.Score("moduleNameResolver.ts")
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocations; expected none", allocs)
+	}
+}
+
 type employee struct {
 	name string
 }
@@ -178,6 +225,20 @@ func TestFindNoSort(t *testing.T) {
 	}
 }
 
+func TestMatchesSortFunc(t *testing.T) {
+	matches := fuzzy.FindNoSort("mnr", []string{"moduleNameResolver.ts", "my name is_Ramsey", "m_n_r"})
+	// short strings first, long strings keep their input order
+	matches.SortFunc(func(a, b fuzzy.Match) int { return min(len(a.Str), 10) - min(len(b.Str), 10) })
+	var got []string
+	for _, match := range matches {
+		got = append(got, match.Str)
+	}
+	want := []string{"m_n_r", "moduleNameResolver.ts", "my name is_Ramsey"}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
 func TestFindFromSource(t *testing.T) {
 	emps := employees{
 		{
@@ -240,6 +301,38 @@ func TestFindFromIter(t *testing.T) {
 	}
 }
 
+func TestMatchSeq(t *testing.T) {
+	emps := employees{{name: "Alice"}, {name: "Bob"}, {name: "Allie"}}
+	var got fuzzy.Matches
+	for match := range fuzzy.MatchSeq("al", emps.Values()) {
+		got = append(got, match)
+	}
+	if diff := pretty.Compare(fuzzy.FindFromIterNoSort("al", emps.Values()), got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestMatchSeqIsLazy(t *testing.T) {
+	var produced int
+	strs := func(yield func(string) bool) {
+		for _, s := range []string{"Bob", "Alice", "Allie", "Al"} {
+			produced++
+			if !yield(s) {
+				return
+			}
+		}
+	}
+	for match := range fuzzy.MatchSeq("al", strs) {
+		if match.Str != "Alice" || match.Index != 1 {
+			t.Errorf("got %v at %v; expected Alice at 1", match.Str, match.Index)
+		}
+		break
+	}
+	if produced != 2 {
+		t.Errorf("produced %v strings; expected matching to stop after 2", produced)
+	}
+}
+
 func TestFindWithRealworldData(t *testing.T) {
 	t.Run("with unreal 4 file names", func(t *testing.T) {
 		cases := []struct {
@@ -258,9 +351,9 @@ func TestFindWithRealworldData(t *testing.T) {
 			},
 			{
 				"lll", 3, []string{
+					"LODLevelItem.h",
+					"LODLevelItem.cpp",
 					"LogFileLogger.cs",
-					"LevelExporterLOD.h",
-					"LockFreeListImpl.h",
 				},
 			},
 			{
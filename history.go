@@ -0,0 +1,182 @@
+package fuzzy
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHistoryHalfLife = 7 * 24 * time.Hour
+	defaultHistoryWeight   = 20
+	// Entries are forgotten once their selections have decayed below this count.
+	minHistoryCount = 0.01
+)
+
+// Booster adds a boost to the score of every match, for example to rank strings the user picked
+// before higher.
+type Booster interface {
+	// Boost returns the number added to the score of a match of s.
+	Boost(s string) int
+}
+
+/*
+History ranks recently and frequently selected strings higher. Every selection
+of a string counts 1 at first and decays over time, halving every HalfLife.
+Pass a History to WithBooster, and the decayed count of a string times Weight
+is added to the score of its matches.
+
+The zero value is an empty History that never decays and doesn't boost, so
+start from NewHistory. A History is safe for concurrent use by multiple
+goroutines.
+*/
+type History struct {
+	// The time it takes for a selection to count half as much.
+	HalfLife time.Duration
+	// The boost of a single selection that hasn't decayed yet.
+	Weight int
+	// Returns the current time. Defaults to time.Now.
+	Now func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]historyEntry
+}
+
+// historyEntry holds the selections of a string.
+type historyEntry struct {
+	// The decayed count of selections at Last.
+	Count float64   `json:"count"`
+	Last  time.Time `json:"last"`
+}
+
+// NewHistory returns an empty History with a HalfLife of a week and a Weight of 20.
+func NewHistory() *History {
+	return &History{
+		HalfLife: defaultHistoryHalfLife,
+		Weight:   defaultHistoryWeight,
+		Now:      time.Now,
+		entries:  make(map[string]historyEntry),
+	}
+}
+
+// Add records a selection of s now.
+func (h *History) Add(s string) {
+	h.AddAt(s, h.now())
+}
+
+// AddAt records a selection of s at t.
+func (h *History) AddAt(s string, t time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.entries == nil {
+		h.entries = make(map[string]historyEntry)
+	}
+	e := h.entries[s]
+	if t.Before(e.Last) {
+		// An earlier selection counts less than one at e.Last.
+		e.Count += h.decay(e.Last.Sub(t))
+	} else {
+		e.Count = e.Count*h.decay(t.Sub(e.Last)) + 1
+		e.Last = t
+	}
+	h.entries[s] = e
+}
+
+// Count returns the decayed count of selections of s.
+func (h *History) Count(s string) float64 {
+	h.mu.RLock()
+	e, ok := h.entries[s]
+	h.mu.RUnlock()
+	if !ok {
+		return 0
+	}
+	return e.Count * h.decay(h.now().Sub(e.Last))
+}
+
+// Boost returns the decayed count of selections of s times Weight, rounded to the nearest integer.
+func (h *History) Boost(s string) int {
+	return int(math.Round(h.Count(s) * float64(h.Weight)))
+}
+
+// Len returns the number of strings in the history.
+func (h *History) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.entries)
+}
+
+/*
+Save writes the history to the file name as JSON, replacing it atomically.
+Strings whose selections have decayed to almost nothing are left out.
+*/
+func (h *History) Save(name string) error {
+	now := h.now()
+	h.mu.RLock()
+	entries := make(map[string]historyEntry, len(h.entries))
+	for s, e := range h.entries {
+		if e.Count*h.decay(now.Sub(e.Last)) >= minHistoryCount {
+			entries[s] = e
+		}
+	}
+	h.mu.RUnlock()
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return writeFile(name, data)
+}
+
+// writeFile writes data to the file name through a temporary file, so that the file is either
+// replaced entirely or left alone.
+func writeFile(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+/*
+Load replaces the history with the one saved to the file name. The error
+satisfies errors.Is(err, fs.ErrNotExist) if the file doesn't exist yet.
+*/
+func (h *History) Load(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	entries := make(map[string]historyEntry)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	h.mu.Lock()
+	h.entries = entries
+	h.mu.Unlock()
+	return nil
+}
+
+// decay returns the factor a count decays by over d. Counts don't grow if d is negative.
+func (h *History) decay(d time.Duration) float64 {
+	if h.HalfLife <= 0 || d <= 0 {
+		return 1
+	}
+	return math.Exp2(-float64(d) / float64(h.HalfLife))
+}
+
+func (h *History) now() time.Time {
+	if h.Now == nil {
+		return time.Now()
+	}
+	return h.Now()
+}
@@ -0,0 +1,146 @@
+package fuzzy_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func newTestHistory(now *time.Time) *fuzzy.History {
+	h := fuzzy.NewHistory()
+	h.HalfLife = time.Hour
+	h.Weight = 100
+	h.Now = func() time.Time { return *now }
+	return h
+}
+
+func TestHistoryBoost(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	h := newTestHistory(&now)
+	h.AddAt("a", now)
+	h.AddAt("b", now.Add(-time.Hour))
+	h.AddAt("c", now.Add(-2*time.Hour))
+	h.AddAt("c", now.Add(-time.Hour))
+	// selections added out of order count like the others
+	h.AddAt("d", now.Add(-time.Hour))
+	h.AddAt("d", now.Add(-2*time.Hour))
+	cases := []struct {
+		str   string
+		boost int
+	}{
+		{"a", 100},
+		{"b", 50},
+		{"c", 75},
+		{"d", 75},
+		{"e", 0},
+	}
+	for _, c := range cases {
+		if boost := h.Boost(c.str); boost != c.boost {
+			t.Errorf("%q: got boost %v; expected %v", c.str, boost, c.boost)
+		}
+	}
+	now = now.Add(time.Hour)
+	if boost := h.Boost("a"); boost != 50 {
+		t.Errorf("got boost %v an hour later; expected 50", boost)
+	}
+}
+
+func TestCompileWithHistory(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	h := newTestHistory(&now)
+	data := []string{"main.go", "cmd/main.go", "main_test.go"}
+	h.Add("main_test.go")
+	// (main = 75) - 8 unmatched chars + 100 boost = 167
+	want := fuzzy.Matches{
+		{
+			Str:            "main_test.go",
+			Index:          2,
+			MatchedIndexes: []int{0, 1, 2, 3},
+			Score:          167,
+		},
+		{
+			Str:            "main.go",
+			Index:          0,
+			MatchedIndexes: []int{0, 1, 2, 3},
+			Score:          72,
+		},
+		{
+			Str:            "cmd/main.go",
+			Index:          1,
+			MatchedIndexes: []int{4, 5, 6, 7},
+			Score:          63,
+		},
+	}
+	got := fuzzy.Compile("main", fuzzy.WithBooster(h)).Find(data)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestHistorySaveLoad(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	h := newTestHistory(&now)
+	h.AddAt("recent", now.Add(-time.Hour))
+	h.AddAt("recent", now)
+	// decayed below the count worth saving
+	h.AddAt("ancient", now.Add(-24*time.Hour))
+	name := filepath.Join(t.TempDir(), "history.json")
+	if err := h.Save(name); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := newTestHistory(&now)
+	if err := loaded.Load(name); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Len() != 1 {
+		t.Errorf("got %v strings; expected 1", loaded.Len())
+	}
+	if got, want := loaded.Count("recent"), h.Count("recent"); got != want {
+		t.Errorf("got count %v; expected %v", got, want)
+	}
+	entries, err := os.ReadDir(filepath.Dir(name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("got %v files; expected only the history", len(entries))
+	}
+}
+
+func TestHistoryLoadMissingFile(t *testing.T) {
+	h := fuzzy.NewHistory()
+	err := h.Load(filepath.Join(t.TempDir(), "missing.json"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("got %v; expected fs.ErrNotExist", err)
+	}
+}
+
+func TestHistoryConcurrentUse(t *testing.T) {
+	h := fuzzy.NewHistory()
+	data := []string{"main.go", "cmd/main.go", "main_test.go"}
+	m := fuzzy.Compile("main", fuzzy.WithBooster(h))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, s := range data {
+				h.Add(s)
+				m.Find(data)
+			}
+		}()
+	}
+	wg.Wait()
+	if h.Len() != len(data) {
+		t.Errorf("got %v strings; expected %v", h.Len(), len(data))
+	}
+}
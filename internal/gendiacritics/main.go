@@ -0,0 +1,88 @@
+/*
+gendiacritics generates diacritics_table.go, which maps runes to their base
+rune without diacritics. It lives in a module of its own so that fuzzy doesn't
+depend on golang.org/x/text. Run it with go generate from the root of the
+repository.
+*/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// entriesPerLine is the number of runes mapped on every line of the table.
+const entriesPerLine = 6
+
+func main() {
+	output := flag.String("o", "diacritics_table.go", "the file to write the table to")
+	flag.Parse()
+	src, err := generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted source of the table.
+func generate() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `// Code generated by gendiacritics from Unicode %s; DO NOT EDIT.
+
+package fuzzy
+
+// baseRunes maps runes to the base rune of their canonical decomposition (NFD) once nonspacing
+// combining marks are removed, e.g. 'é' to 'e'. Runes that decompose into more than one base rune,
+// such as Hangul syllables, are left out.
+var baseRunes = map[rune]rune{
+`, norm.Version)
+	var n int
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		base, ok := baseRune(r)
+		if !ok {
+			continue
+		}
+		if n%entriesPerLine == 0 {
+			b.WriteString("\t")
+		}
+		fmt.Fprintf(&b, "0x%04X: 0x%04X,", r, base)
+		if n++; n%entriesPerLine == 0 {
+			b.WriteString("\n")
+		} else {
+			b.WriteString(" ")
+		}
+	}
+	b.WriteString("\n}\n")
+	return format.Source(b.Bytes())
+}
+
+// baseRune returns the base rune of r if r decomposes into a single base rune followed by one or
+// more nonspacing combining marks.
+func baseRune(r rune) (rune, bool) {
+	if !utf8.ValidRune(r) {
+		return 0, false
+	}
+	var base []rune
+	var marks int
+	for _, c := range norm.NFD.String(string(r)) {
+		if unicode.Is(unicode.Mn, c) {
+			marks++
+		} else {
+			base = append(base, c)
+		}
+	}
+	if len(base) != 1 || marks == 0 {
+		return 0, false
+	}
+	return base[0], true
+}
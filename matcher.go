@@ -0,0 +1,411 @@
+package fuzzy
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Option configures a Matcher.
+type Option func(*Options)
+
+// WithOptions replaces all options of a Matcher with opts.
+func WithOptions(opts Options) Option {
+	return func(o *Options) {
+		*o = opts
+	}
+}
+
+// WithAlgorithm sets the algorithm used to align the pattern.
+func WithAlgorithm(algorithm Algorithm) Option {
+	return func(o *Options) {
+		o.Algorithm = algorithm
+	}
+}
+
+// WithSeparators sets the runes that separate words.
+func WithSeparators(separators string) Option {
+	return func(o *Options) {
+		o.Separators = separators
+	}
+}
+
+// WithMultiTerm splits the pattern into terms separated by whitespace.
+func WithMultiTerm() Option {
+	return func(o *Options) {
+		o.MultiTerm = true
+	}
+}
+
+// WithCaseMode sets how the case of runes is compared.
+func WithCaseMode(mode CaseMode) Option {
+	return func(o *Options) {
+		o.CaseMode = mode
+	}
+}
+
+// WithMaxTypos allows fuzzy matches with up to n typos.
+func WithMaxTypos(n int) Option {
+	return func(o *Options) {
+		o.MaxTypos = n
+	}
+}
+
+// WithPathMode scores match strings as file paths, preferring matches in the basename.
+func WithPathMode() Option {
+	return func(o *Options) {
+		o.PathMode = true
+	}
+}
+
+// WithPathSegments matches pattern segments separated by slashes against successive path segments.
+func WithPathSegments() Option {
+	return func(o *Options) {
+		o.PathSegments = true
+	}
+}
+
+// WithIgnoreDiacritics ignores diacritics in the pattern and match strings.
+func WithIgnoreDiacritics() Option {
+	return func(o *Options) {
+		o.IgnoreDiacritics = true
+	}
+}
+
+// WithMinScore drops matches with a normalized score below minScore.
+func WithMinScore(minScore float64) Option {
+	return func(o *Options) {
+		o.MinScore = minScore
+	}
+}
+
+// WithTieBreakers orders matches with equal scores by the given tie-breakers in turn.
+func WithTieBreakers(tieBreakers ...TieBreaker) Option {
+	return func(o *Options) {
+		o.TieBreakers = tieBreakers
+	}
+}
+
+// WithCompare orders sorted results by compare instead of by score.
+func WithCompare(compare func(a, b Match) int) Option {
+	return func(o *Options) {
+		o.Compare = compare
+	}
+}
+
+// WithBooster adds the boost of b to the score of every match.
+func WithBooster(b Booster) Option {
+	return func(o *Options) {
+		o.Booster = b
+	}
+}
+
+// WithConcurrency sets the number of goroutines used to match a Source.
+func WithConcurrency(n int) Option {
+	return func(o *Options) {
+		o.Concurrency = n
+	}
+}
+
+// Matcher is a compiled pattern that can be matched against any number of strings. The pattern is
+// preprocessed once by Compile. A Matcher is safe for concurrent use by multiple goroutines.
+type Matcher struct {
+	pattern string
+	// A string matches if it matches at least one term of every group.
+	groups [][]term
+	// The number of indexes reported for a match.
+	indexCap int
+	// The highest score any string could get.
+	maxScore int
+	opts     Options
+}
+
+// Compile prepares pattern for matching. Options are applied on top of DefaultOptions in order.
+func Compile(pattern string, opts ...Option) *Matcher {
+	return compile(pattern, applyOptions(opts))
+}
+
+// applyOptions applies opts on top of DefaultOptions.
+func applyOptions(opts []Option) Options {
+	o := DefaultOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func compile(pattern string, opts Options) *Matcher {
+	var groups [][]term
+	if opts.MultiTerm {
+		for _, field := range strings.Fields(pattern) {
+			groups = append(groups, []term{{kind: termFuzzy, runes: []rune(field)}})
+		}
+	} else if pattern != "" {
+		groups = [][]term{{{kind: termFuzzy, runes: []rune(pattern)}}}
+	}
+	return newMatcher(pattern, groups, opts)
+}
+
+func newMatcher(pattern string, groups [][]term, opts Options) *Matcher {
+	m := &Matcher{
+		pattern: pattern,
+		groups:  groups,
+		opts:    opts,
+	}
+	m.opts.separators = newSeparatorSet(opts.Separators)
+	for _, group := range groups {
+		var groupCap int
+		for i := range group {
+			t := &group[i]
+			if opts.IgnoreDiacritics {
+				if stripped := stripDiacritics(slices.Clone(t.runes)); len(stripped) > 0 {
+					t.runes = stripped
+				}
+			}
+			switch opts.CaseMode {
+			case CaseSensitive:
+				t.caseSensitive = true
+			case CaseSmart:
+				t.caseSensitive = slices.ContainsFunc(t.runes, unicode.IsUpper)
+			}
+			if opts.PathSegments {
+				splitSegments(t)
+			}
+			if !t.inverse {
+				groupCap = max(groupCap, len(t.runes))
+			}
+		}
+		m.indexCap += groupCap
+	}
+	m.maxScore = m.maxPatternScore()
+	return m
+}
+
+// String returns the pattern the Matcher was compiled from.
+func (m *Matcher) String() string {
+	return m.pattern
+}
+
+// empty reports whether the pattern is empty. An empty pattern matches nothing.
+func (m *Matcher) empty() bool {
+	return len(m.groups) == 0
+}
+
+// Match matches s against the pattern. The returned Match has an Index of 0. It reports false if s
+// doesn't match or the pattern is empty.
+func (m *Matcher) Match(s string) (Match, bool) {
+	if m.empty() {
+		return Match{}, false
+	}
+	var sc scratch
+	score, indexes, ok := m.match(s, make([]int, 0, m.indexCap), &sc)
+	if !ok {
+		return Match{}, false
+	}
+	return Match{Str: s, MatchedIndexes: indexes, Score: score}, true
+}
+
+// Score matches s against the pattern and returns only the score. It reports false if s doesn't
+// match or the pattern is empty. Unlike Match it doesn't allocate MatchedIndexes.
+func (m *Matcher) Score(s string) (int, bool) {
+	if m.empty() {
+		return 0, false
+	}
+	var sc scratch
+	// Most patterns fit in buf, so indexes don't have to be allocated on the heap.
+	var buf [32]int
+	score, _, ok := m.match(s, buf[:0], &sc)
+	if !ok {
+		return 0, false
+	}
+	return score, true
+}
+
+// Find looks up the pattern in data and returns matches in descending order of match quality.
+func (m *Matcher) Find(data []string) Matches {
+	return m.FindFrom(stringSource(data))
+}
+
+// FindFrom is an alternative implementation of Find using a Source instead of a list of strings.
+func (m *Matcher) FindFrom(data Source) Matches {
+	matches := m.findFromNoSort(data)
+	m.sort(matches)
+	return matches
+}
+
+// FindFromIter is an alternative implementation of Find using an iterator instead of a list of
+// strings.
+func (m *Matcher) FindFromIter(it iter.Seq[string]) Matches {
+	matches := m.findFromIterNoSort(it, 0)
+	m.sort(matches)
+	return matches
+}
+
+// findFromIterNoSort matches the strings of it in order. The first string has the index offset.
+func (m *Matcher) findFromIterNoSort(it iter.Seq[string], offset int) Matches {
+	var matches Matches
+	for match := range m.all(it, offset) {
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// all yields a Match for every string of it that matches, in order. The first string has the index
+// offset. Every yielded Match owns its MatchedIndexes.
+func (m *Matcher) all(it iter.Seq[string], offset int) iter.Seq[Match] {
+	return func(yield func(Match) bool) {
+		if m.empty() {
+			return
+		}
+		var matchedIndexes []int
+		var sc scratch
+		i := offset
+		for matchStr := range it {
+			var match Match
+			match.Str = matchStr
+			match.Index = i
+			i++
+			if matchedIndexes != nil {
+				match.MatchedIndexes = matchedIndexes
+			} else {
+				match.MatchedIndexes = make([]int, 0, m.indexCap)
+			}
+			var ok bool
+			match.Score, match.MatchedIndexes, ok = m.match(matchStr, match.MatchedIndexes, &sc)
+			if !ok {
+				matchedIndexes = match.MatchedIndexes[:0] // Recycle match index slice
+				continue
+			}
+			matchedIndexes = nil
+			if !yield(match) {
+				return
+			}
+		}
+	}
+}
+
+// compare returns a negative number if a ranks before b and a positive number if it ranks after b.
+// Matches with equal scores are ordered by the tie-breakers and then keep their input order, unless
+// the order is replaced by Options.Compare.
+func (m *Matcher) compare(a, b Match) int {
+	if m.opts.Compare != nil {
+		if c := m.opts.Compare(a, b); c != 0 {
+			return c
+		}
+		return ByIndex(a, b)
+	}
+	if c := cmp.Compare(b.Score, a.Score); c != 0 {
+		return c
+	}
+	for _, tieBreaker := range m.opts.TieBreakers {
+		if c := tieBreaker(a, b); c != 0 {
+			return c
+		}
+	}
+	return ByIndex(a, b)
+}
+
+// sort sorts matches in descending order of match quality.
+func (m *Matcher) sort(matches Matches) {
+	slices.SortFunc(matches, m.compare)
+}
+
+// match matches s and returns its score along with indexes extended by the byte index of every
+// matched rune. indexes must be empty.
+func (m *Matcher) match(s string, indexes []int, sc *scratch) (int, []int, bool) {
+	str := s
+	// Limit matching to the first NUL rune, if any. We could maybe replace it
+	// with whitespace, but this way doesn't allocate so much, and the presence
+	// of NULs is most often an error by the library user.
+	if nullI := strings.IndexRune(s, 0); nullI > -1 {
+		s = s[:nullI]
+	}
+	sc.reset()
+	sc.events = sc.events[:0]
+	var origin []int
+	if m.opts.IgnoreDiacritics {
+		s, origin = sc.stripDiacritics(s)
+	}
+	score, indexes, ok := m.matchGroups(s, indexes, sc)
+	if !ok {
+		return 0, indexes, false
+	}
+	// apply penalty for each unmatched character
+	score += (len(s) - len(indexes)) * m.opts.UnmatchedCharPenalty
+	sc.record(eventUnmatched, -1, (len(s)-len(indexes))*m.opts.UnmatchedCharPenalty)
+	if m.opts.PathMode {
+		score, indexes = m.matchPath(s, score, indexes, sc)
+	}
+	if m.opts.MinScore > 0 && m.NormalizedScore(score) < m.opts.MinScore {
+		return 0, indexes, false
+	}
+	if m.opts.Booster != nil {
+		boost := m.opts.Booster.Boost(str)
+		score += boost
+		sc.record(eventBoost, -1, boost)
+	}
+	if origin != nil {
+		for i, j := range indexes {
+			indexes[i] = origin[j]
+		}
+		for i, e := range sc.events {
+			if e.index >= 0 {
+				sc.events[i].index = origin[e.index]
+			}
+		}
+	}
+	return score, indexes, true
+}
+
+// matchGroups matches every group of terms against s and returns the sum of their scores along
+// with indexes extended by the byte index of every matched rune. indexes must be empty.
+func (m *Matcher) matchGroups(s string, indexes []int, sc *scratch) (int, []int, bool) {
+	if len(m.groups) == 1 && len(m.groups[0]) == 1 {
+		return m.matchTerm(&m.groups[0][0], s, indexes, sc)
+	}
+	var score, typos int
+	for _, group := range m.groups {
+		// Every group is matched on its own, starting with no indexes.
+		groupScore, groupIndexes, ok := m.matchGroup(group, s, indexes[len(indexes):], sc)
+		// MaxTypos limits the typos of the whole match.
+		if typos += sc.typos; !ok || typos > m.opts.MaxTypos {
+			return 0, indexes, false
+		}
+		score += groupScore
+		indexes = append(indexes, groupIndexes...)
+	}
+	sc.typos = typos
+	// Terms may match the same runes.
+	slices.Sort(indexes)
+	return score, slices.Compact(indexes), true
+}
+
+// matchGroup matches the terms of group against s and returns the score and indexes of the best
+// matching term. Terms with fewer typos are better than terms with a higher score. indexes must be
+// empty.
+func (m *Matcher) matchGroup(group []term, s string, indexes []int, sc *scratch) (int, []int, bool) {
+	if len(group) == 1 {
+		return m.matchTerm(&group[0], s, indexes, sc)
+	}
+	bestScore, bestTypos, best := 0, 0, -1
+	mark := len(sc.events)
+	for i := range group {
+		score, termIndexes, ok := m.matchTerm(&group[i], s, indexes, sc)
+		if ok && (best < 0 || sc.typos < bestTypos || sc.typos == bestTypos && score > bestScore) {
+			bestScore, bestTypos, best = score, sc.typos, i
+			sc.group = append(sc.group[:0], termIndexes...)
+		}
+	}
+	if best < 0 {
+		return 0, indexes, false
+	}
+	sc.typos = bestTypos
+	if sc.explaining {
+		// Only the contributions of the best term count, so it is matched again.
+		sc.events = sc.events[:mark]
+		m.matchTerm(&group[best], s, indexes, sc)
+	}
+	return bestScore, append(indexes, sc.group...), true
+}
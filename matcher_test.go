@@ -0,0 +1,256 @@
+package fuzzy_test
+
+import (
+	"cmp"
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestMatcherMatch(t *testing.T) {
+	m := fuzzy.Compile("mnr")
+	got, ok := m.Match("moduleNameResolver.ts")
+	if !ok {
+		t.Fatal("got no match; expected a match")
+	}
+	want := fuzzy.Match{
+		Str:            "moduleNameResolver.ts",
+		Index:          0,
+		MatchedIndexes: []int{0, 6, 10},
+		Score:          32,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+	if _, ok := m.Match("game.cpp"); ok {
+		t.Error("got a match for game.cpp; expected none")
+	}
+	if _, ok := fuzzy.Compile("").Match("cat"); ok {
+		t.Error("got a match for an empty pattern; expected none")
+	}
+}
+
+func TestMatcherFind(t *testing.T) {
+	data := []string{"moduleNameResolver.ts", "game.cpp", "my name is_Ramsey"}
+	m := fuzzy.Compile("mnr")
+	if diff := pretty.Compare(fuzzy.Find("mnr", data), m.Find(data)); diff != "" {
+		t.Errorf("%v", diff)
+	}
+	emps := employees{{name: "Alice"}, {name: "Bob"}, {name: "Allie"}}
+	if diff := pretty.Compare(fuzzy.FindFrom("al", emps), fuzzy.Compile("al").FindFrom(emps)); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestCompileWithOptions(t *testing.T) {
+	opts := fuzzy.DefaultOptions
+	opts.CamelCaseMatchBonus = 100
+	data := []string{"moduleNameResolver.ts", "my name is_Ramsey"}
+	want := fuzzy.FindWithOptions("mnr", data, opts)
+	got := fuzzy.Compile("mnr", fuzzy.WithOptions(opts)).Find(data)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+	opts = fuzzy.DefaultOptions
+	opts.Algorithm = fuzzy.AlgorithmOptimal
+	want = fuzzy.FindWithOptions("ab", []string{"xab_ab"}, opts)
+	got = fuzzy.Compile("ab", fuzzy.WithAlgorithm(fuzzy.AlgorithmOptimal)).Find([]string{"xab_ab"})
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestMatcherConcurrentUse(t *testing.T) {
+	bytes, err := os.ReadFile("testdata/ue4_filenames.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filenames := strings.Split(string(bytes), "\n")
+	for _, algorithm := range []fuzzy.Algorithm{fuzzy.AlgorithmGreedy, fuzzy.AlgorithmOptimal} {
+		m := fuzzy.Compile("lll", fuzzy.WithAlgorithm(algorithm))
+		want := m.Find(filenames)
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if diff := pretty.Compare(want, m.Find(filenames)); diff != "" {
+					t.Errorf("%v", diff)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func TestCompileWithMultiTerm(t *testing.T) {
+	cases := []struct {
+		pattern string
+		data    []string
+		matches fuzzy.Matches
+	}{
+		// terms match in any order
+		{
+			"http handler", []string{"handler/http.go", "http_server.go", "httpHandler.go"}, fuzzy.Matches{
+				{
+					Str:            "httpHandler.go",
+					Index:          2,
+					MatchedIndexes: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+					Score:          1897,
+				},
+				{
+					Str:            "handler/http.go",
+					Index:          0,
+					MatchedIndexes: []int{0, 1, 2, 3, 4, 5, 6, 8, 9, 10, 11},
+					Score:          1896,
+				},
+			},
+		},
+		// terms may match the same runes, (a = 10, b = 5) + (b = 0 - 5 leading chars, a = 5) = 15
+		{
+			"ab ba", []string{"aba"}, fuzzy.Matches{
+				{
+					Str:            "aba",
+					Index:          0,
+					MatchedIndexes: []int{0, 1, 2},
+					Score:          15,
+				},
+			},
+		},
+		// whitespace only patterns have no terms
+		{
+			"  ", []string{"  "}, nil,
+		},
+	}
+	for _, c := range cases {
+		matches := fuzzy.Compile(c.pattern, fuzzy.WithMultiTerm()).Find(c.data)
+		if diff := pretty.Compare(c.matches, matches); diff != "" {
+			t.Errorf("%v: %v", c.pattern, diff)
+		}
+	}
+	// whitespace is matched literally by default
+	if got := fuzzy.Compile("http handler").Find([]string{"handler/http.go", "httpHandler.go"}); got != nil {
+		t.Errorf("got %v; expected no Matches", got)
+	}
+}
+
+func TestCompileWithCaseMode(t *testing.T) {
+	data := []string{"readme_test.go", "README.md", "ReadMe.txt"}
+	cases := []struct {
+		pattern string
+		mode    fuzzy.CaseMode
+		found   []string
+	}{
+		{"readme", fuzzy.CaseInsensitive, []string{"readme_test.go", "README.md", "ReadMe.txt"}},
+		{"README", fuzzy.CaseInsensitive, []string{"readme_test.go", "README.md", "ReadMe.txt"}},
+		{"readme", fuzzy.CaseSensitive, []string{"readme_test.go"}},
+		{"README", fuzzy.CaseSensitive, []string{"README.md"}},
+		{"readme", fuzzy.CaseSmart, []string{"readme_test.go", "README.md", "ReadMe.txt"}},
+		{"README", fuzzy.CaseSmart, []string{"README.md"}},
+		{"RM", fuzzy.CaseSmart, []string{"README.md", "ReadMe.txt"}},
+	}
+	for _, c := range cases {
+		for _, algorithm := range []fuzzy.Algorithm{fuzzy.AlgorithmGreedy, fuzzy.AlgorithmOptimal} {
+			m := fuzzy.Compile(c.pattern, fuzzy.WithCaseMode(c.mode), fuzzy.WithAlgorithm(algorithm))
+			var found []string
+			for _, s := range data {
+				if _, ok := m.Match(s); ok {
+					found = append(found, s)
+				}
+			}
+			if diff := pretty.Compare(c.found, found); diff != "" {
+				t.Errorf("%v with mode %v and algorithm %v: %v", c.pattern, c.mode, algorithm, diff)
+			}
+		}
+	}
+}
+
+func TestCompileWithCaseModeKeepsCamelCaseBonus(t *testing.T) {
+	want := fuzzy.Find("mnr", []string{"moduleNameResolver.ts"})
+	got := fuzzy.Compile("mNR", fuzzy.WithCaseMode(fuzzy.CaseSensitive)).Find([]string{"moduleNameResolver.ts"})
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestParseQueryWithSmartCase(t *testing.T) {
+	// every term is considered on its own
+	m := fuzzy.ParseQuery("'READ md$", fuzzy.WithCaseMode(fuzzy.CaseSmart))
+	var found []string
+	for _, s := range []string{"readme.md", "README.md", "README.MD", "READ.txt"} {
+		if _, ok := m.Match(s); ok {
+			found = append(found, s)
+		}
+	}
+	if diff := pretty.Compare([]string{"README.md", "README.MD"}, found); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestCompileWithCompare(t *testing.T) {
+	data := []string{"main.go", "cmd/main.go", "main_test.go"}
+	// Recently used files get a boost on top of their score.
+	recency := map[int]int{1: 100, 2: 10}
+	rank := func(m fuzzy.Match) int { return m.Score + recency[m.Index] }
+	m := fuzzy.Compile("main", fuzzy.WithCompare(func(a, b fuzzy.Match) int {
+		return cmp.Compare(rank(b), rank(a))
+	}))
+	var got []string
+	for _, match := range m.Find(data) {
+		got = append(got, match.Str)
+	}
+	want := []string{"cmd/main.go", "main_test.go", "main.go"}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestCompileWithCompareConcurrently(t *testing.T) {
+	// enough strings for several goroutines, which must look up the same indexes
+	var data []string
+	for range 1000 {
+		data = append(data, "main.go", "cmd/main.go", "main_test.go")
+	}
+	recency := map[int]int{2998: 100, 1: 10}
+	rank := func(m fuzzy.Match) int { return m.Score + recency[m.Index] }
+	compare := fuzzy.WithCompare(func(a, b fuzzy.Match) int {
+		return cmp.Compare(rank(b), rank(a))
+	})
+	want := fuzzy.Compile("main", compare).Find(data)
+	if want[0].Index != 2998 || want[1].Index != 1 {
+		t.Fatalf("got %v and %v first; expected 2998 and 1", want[0].Index, want[1].Index)
+	}
+	m := fuzzy.Compile("main", compare, fuzzy.WithConcurrency(4))
+	if diff := pretty.Compare(want, m.Find(data)); diff != "" {
+		t.Errorf("Find: %v", diff)
+	}
+	if diff := pretty.Compare(want[:5], m.FindTopK(data, 5)); diff != "" {
+		t.Errorf("FindTopK: %v", diff)
+	}
+	got, err := m.FindContext(context.Background(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("FindContext: %v", diff)
+	}
+}
+
+func TestCompileWithCompareKeepsInputOrder(t *testing.T) {
+	data := []string{"b", "a", "ab", "ba"}
+	m := fuzzy.Compile("a", fuzzy.WithCompare(func(a, b fuzzy.Match) int { return 0 }))
+	var got []string
+	for _, match := range m.Find(data) {
+		got = append(got, match.Str)
+	}
+	want := []string{"a", "ab", "ba"}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
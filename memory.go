@@ -0,0 +1,209 @@
+package fuzzy
+
+import (
+	"cmp"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultMemoryWeight     = 50
+	defaultMemoryMaxEntries = 1000
+)
+
+/*
+Memory learns which result the user picks for a pattern. Record a selection
+with the pattern it was made for, and pass the Booster returned by For to
+WithBooster when matching that pattern or any pattern it is a prefix of, so
+that typing "cfg" or "cfg/c" again ranks the file picked for "cfg" first.
+
+Every selection recorded for a prefix of the pattern adds Weight to the boost.
+Once Memory holds more than MaxEntries pairs of pattern and selection, the
+least recently used pairs are forgotten.
+
+The zero value is an empty Memory that doesn't boost, so start from NewMemory.
+A Memory is safe for concurrent use by multiple goroutines.
+*/
+type Memory struct {
+	// The boost of a single selection.
+	Weight int
+	// The maximum number of pairs of pattern and selection to keep. 0 means no limit.
+	MaxEntries int
+	// Returns the current time. Defaults to time.Now.
+	Now func() time.Time
+
+	mu sync.RWMutex
+	// The selections recorded for every pattern.
+	entries map[string]map[string]memoryEntry
+	// The number of pairs of pattern and selection in entries.
+	n int
+	// The sequence number of the last use of a pair.
+	seq uint64
+}
+
+// memoryEntry holds the selections of a string for a pattern.
+type memoryEntry struct {
+	Count int       `json:"count"`
+	Last  time.Time `json:"last"`
+	// Orders the uses of the pairs even if the clock doesn't.
+	seq uint64
+}
+
+// NewMemory returns an empty Memory with a Weight of 50 that keeps up to 1000 selections.
+func NewMemory() *Memory {
+	return &Memory{
+		Weight:     defaultMemoryWeight,
+		MaxEntries: defaultMemoryMaxEntries,
+		Now:        time.Now,
+		entries:    make(map[string]map[string]memoryEntry),
+	}
+}
+
+// Record remembers that s was selected among the matches of pattern. Surrounding whitespace of
+// the pattern is ignored, and so are empty patterns.
+func (m *Memory) Record(pattern, s string) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return
+	}
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = make(map[string]map[string]memoryEntry)
+	}
+	selections := m.entries[pattern]
+	if selections == nil {
+		selections = make(map[string]memoryEntry)
+		m.entries[pattern] = selections
+	}
+	e, ok := selections[s]
+	if !ok {
+		m.n++
+	}
+	m.seq++
+	selections[s] = memoryEntry{Count: e.Count + 1, Last: now, seq: m.seq}
+	m.evict()
+}
+
+/*
+For returns a Booster for pattern that boosts the selections recorded so far
+for pattern and every prefix of it. Later selections don't affect the returned
+Booster, so call For again for every search.
+*/
+func (m *Memory) For(pattern string) Booster {
+	pattern = strings.TrimSpace(pattern)
+	var b memoryBooster
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for i := range pattern {
+		// Every prefix ends right before a rune, and the last one is the whole pattern.
+		_, size := utf8.DecodeRuneInString(pattern[i:])
+		for s, e := range m.entries[pattern[:i+size]] {
+			if b == nil {
+				b = make(memoryBooster)
+			}
+			b[s] += e.Count * m.Weight
+		}
+	}
+	return b
+}
+
+// Len returns the number of pairs of pattern and selection in the memory.
+func (m *Memory) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.n
+}
+
+// Save writes the memory to the file name as JSON, replacing it atomically.
+func (m *Memory) Save(name string) error {
+	m.mu.RLock()
+	data, err := json.Marshal(m.entries)
+	m.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	return writeFile(name, data)
+}
+
+/*
+Load replaces the memory with the one saved to the file name, forgetting the
+least recently used selections beyond MaxEntries. The error satisfies
+errors.Is(err, fs.ErrNotExist) if the file doesn't exist yet.
+*/
+func (m *Memory) Load(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	entries := make(map[string]map[string]memoryEntry)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	// The pairs are used in the order of their last selection.
+	type pair struct {
+		pattern, s string
+		last       time.Time
+	}
+	var pairs []pair
+	for pattern, selections := range entries {
+		for s, e := range selections {
+			pairs = append(pairs, pair{pattern, s, e.Last})
+		}
+	}
+	slices.SortFunc(pairs, func(a, b pair) int {
+		return cmp.Or(a.last.Compare(b.last), strings.Compare(a.pattern, b.pattern), strings.Compare(a.s, b.s))
+	})
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries, m.n = entries, len(pairs)
+	for i, p := range pairs {
+		e := entries[p.pattern][p.s]
+		e.seq = uint64(i + 1)
+		entries[p.pattern][p.s] = e
+	}
+	m.seq = uint64(len(pairs))
+	m.evict()
+	return nil
+}
+
+// evict forgets the least recently used selections until at most MaxEntries are left. m.mu must
+// be held for writing.
+func (m *Memory) evict() {
+	for m.MaxEntries > 0 && m.n > m.MaxEntries {
+		var oldestPattern, oldest string
+		seq := m.seq + 1
+		for pattern, selections := range m.entries {
+			for s, e := range selections {
+				if e.seq < seq {
+					oldestPattern, oldest, seq = pattern, s, e.seq
+				}
+			}
+		}
+		delete(m.entries[oldestPattern], oldest)
+		if len(m.entries[oldestPattern]) == 0 {
+			delete(m.entries, oldestPattern)
+		}
+		m.n--
+	}
+}
+
+func (m *Memory) now() time.Time {
+	if m.Now == nil {
+		return time.Now()
+	}
+	return m.Now()
+}
+
+// memoryBooster maps the strings selected for a pattern to their boost.
+type memoryBooster map[string]int
+
+func (b memoryBooster) Boost(s string) int {
+	return b[s]
+}
@@ -0,0 +1,156 @@
+package fuzzy_test
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+// newTestMemory returns a Memory whose clock advances a second on every selection.
+func newTestMemory() *fuzzy.Memory {
+	m := fuzzy.NewMemory()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.Now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	return m
+}
+
+func TestMemoryBoost(t *testing.T) {
+	m := newTestMemory()
+	m.Record("cfg", "internal/config/config.go")
+	m.Record(" cfg ", "internal/config/config.go")
+	m.Record("cf", "cmd/fuzzy.go")
+	m.Record("cfgx", "config.xml")
+	m.Record("", "ignored.go")
+	cases := []struct {
+		pattern string
+		str     string
+		boost   int
+	}{
+		{"cfg", "internal/config/config.go", 100},
+		{"cfg/c", "internal/config/config.go", 100},
+		{"cfg", "cmd/fuzzy.go", 50},
+		{"cfg", "config.xml", 0},
+		{"c", "internal/config/config.go", 0},
+		{"", "ignored.go", 0},
+	}
+	for _, c := range cases {
+		if boost := m.For(c.pattern).Boost(c.str); boost != c.boost {
+			t.Errorf("%q for %q: got boost %v; expected %v", c.str, c.pattern, boost, c.boost)
+		}
+	}
+}
+
+func TestCompileWithMemory(t *testing.T) {
+	m := newTestMemory()
+	data := []string{"cfg.go", "internal/config/config.go"}
+	m.Record("cfg", "internal/config/config.go")
+	cases := []struct {
+		pattern string
+		want    []string
+	}{
+		{"cfg", []string{"internal/config/config.go", "cfg.go"}},
+		{"cfgc", []string{"internal/config/config.go"}},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, match := range fuzzy.Compile(c.pattern, fuzzy.WithBooster(m.For(c.pattern))).Find(data) {
+			got = append(got, match.Str)
+		}
+		if diff := pretty.Compare(c.want, got); diff != "" {
+			t.Errorf("%q: %v", c.pattern, diff)
+		}
+	}
+}
+
+func TestMemoryEviction(t *testing.T) {
+	m := newTestMemory()
+	m.MaxEntries = 2
+	m.Record("a", "a.go")
+	m.Record("b", "b.go")
+	m.Record("a", "a.go")
+	m.Record("c", "c.go")
+	if m.Len() != 2 {
+		t.Errorf("got %v selections; expected 2", m.Len())
+	}
+	for _, c := range []struct {
+		pattern string
+		boost   int
+	}{
+		{"a", 100},
+		{"b", 0},
+		{"c", 50},
+	} {
+		if boost := m.For(c.pattern).Boost(c.pattern + ".go"); boost != c.boost {
+			t.Errorf("%q: got boost %v; expected %v", c.pattern, boost, c.boost)
+		}
+	}
+}
+
+func TestMemoryEvictionWithFixedClock(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	// map order varies between runs, so evict a few times
+	for i := 0; i < 100; i++ {
+		m := fuzzy.NewMemory()
+		m.MaxEntries = 2
+		m.Now = func() time.Time { return now }
+		m.Record("a", "a.go")
+		m.Record("b", "b.go")
+		m.Record("c", "c.go")
+		if boost := m.For("c").Boost("c.go"); boost != 50 {
+			t.Fatalf("got boost %v for the latest selection; expected 50", boost)
+		}
+		if boost := m.For("a").Boost("a.go"); boost != 0 {
+			t.Fatalf("got boost %v for the oldest selection; expected 0", boost)
+		}
+	}
+}
+
+func TestMemorySaveLoad(t *testing.T) {
+	m := newTestMemory()
+	m.Record("cfg", "internal/config/config.go")
+	m.Record("cfg", "config.xml")
+	m.Record("main", "cmd/main.go")
+	name := filepath.Join(t.TempDir(), "memory.json")
+	if err := m.Save(name); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := newTestMemory()
+	loaded.MaxEntries = 2
+	if err := loaded.Load(name); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Len() != 2 {
+		t.Errorf("got %v selections; expected 2", loaded.Len())
+	}
+	for _, c := range []struct {
+		pattern string
+		str     string
+		boost   int
+	}{
+		{"cfg", "internal/config/config.go", 0},
+		{"cfg", "config.xml", 50},
+		{"main", "cmd/main.go", 50},
+	} {
+		if boost := loaded.For(c.pattern).Boost(c.str); boost != c.boost {
+			t.Errorf("%q for %q: got boost %v; expected %v", c.str, c.pattern, boost, c.boost)
+		}
+	}
+}
+
+func TestMemoryLoadMissingFile(t *testing.T) {
+	m := fuzzy.NewMemory()
+	err := m.Load(filepath.Join(t.TempDir(), "missing.json"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("got %v; expected fs.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,74 @@
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+)
+
+/*
+NormalizedScore maps a score returned for the pattern to a relevance between 0
+and 1 by dividing it by the highest score any string could get. Scores of 0 or
+less are mapped to 0. Unlike scores, normalized scores can be compared across
+patterns and combined with other signals.
+*/
+func (m *Matcher) NormalizedScore(score int) float64 {
+	if score <= 0 || m.maxScore <= 0 {
+		return 0
+	}
+	return min(float64(score)/float64(m.maxScore), 1)
+}
+
+/*
+NormalizedScore is a convenience wrapper around Matcher.NormalizedScore for a
+score returned by Find and friends.
+*/
+func NormalizedScore(pattern string, score int) float64 {
+	return compile(pattern, DefaultOptions).NormalizedScore(score)
+}
+
+// maxPatternScore returns an upper bound of the score of any string. Penalties are assumed to be zero at
+// best and only positive bonuses are counted.
+func (m *Matcher) maxPatternScore() int {
+	var score int
+	for _, group := range m.groups {
+		var groupScore int
+		for i := range group {
+			groupScore = max(groupScore, maxTermScore(&group[i], &m.opts))
+		}
+		score += groupScore
+	}
+	if m.opts.PathMode {
+		score += m.indexCap*max(m.opts.BasenameMatchBonus, 0) + max(m.opts.FullBasenameMatchBonus, 0)
+	}
+	return score
+}
+
+// maxTermScore returns an upper bound of the score of matching t against any string.
+func maxTermScore(t *term, opts *Options) int {
+	if t.inverse {
+		return 0
+	}
+	if t.segments != nil {
+		score := (len(t.segments) - 1) * max(opts.AdjacentSegmentBonus, 0)
+		for i := range t.segments {
+			score += maxTermScore(&t.segments[i], opts)
+		}
+		return score
+	}
+	// A rune starting a word usually earns either the camel case or the separator bonus, but both if
+	// a letter or digit is a separator.
+	camel, separator := max(opts.CamelCaseMatchBonus, 0), max(opts.MatchFollowingSeparatorBonus, 0)
+	word := max(camel, separator)
+	if strings.ContainsFunc(opts.Separators, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+		word = camel + separator
+	}
+	n := len(t.runes)
+	// The first rune either starts the string or a word elsewhere, and every other rune can start a
+	// word and be adjacent to the previous one.
+	score := max(max(opts.FirstCharMatchBonus, 0), word) + (n-1)*word
+	score += max(adjacentBonusTotal(n-1, opts), 0)
+	if t.kind == termFuzzy && n >= 2 {
+		score += max(opts.AcronymMatchBonus, 0)
+	}
+	return score
+}
@@ -0,0 +1,98 @@
+package fuzzy_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestNormalizedScore(t *testing.T) {
+	cases := []struct {
+		pattern    string
+		str        string
+		normalized float64
+	}{
+		// mnr can score at most (m = 20, n = 20, r = 20) + (n = 5, r = 15) adjacent + 30 acronym = 110
+		{"mnr", "moduleNameResolver.ts", 32.0 / 110},
+		{"mnr", "my name is_Ramsey", 36.0 / 110},
+		{"mnr", "m_n_r", 78.0 / 110},
+		{"mnr", "mnr", 30.0 / 110},
+		{"mnr", "the manor", 0},
+		// the first rune may follow a separator instead of starting the string
+		{"a", "_a", 14.0 / 20},
+		{"a", "a", 10.0 / 20},
+	}
+	for _, c := range cases {
+		score, ok := fuzzy.Score(c.pattern, c.str)
+		if !ok {
+			t.Errorf("%q didn't match %q", c.pattern, c.str)
+			continue
+		}
+		if normalized := fuzzy.NormalizedScore(c.pattern, score); normalized != c.normalized {
+			t.Errorf("%q in %q: got normalized score %v; expected %v", c.pattern, c.str, normalized, c.normalized)
+		}
+	}
+}
+
+func TestNormalizedScoreBelowMaximum(t *testing.T) {
+	bytes, err := os.ReadFile("testdata/ue4_filenames.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filenames := strings.Split(string(bytes), "\n")
+	// short strings whose first matched rune follows a separator
+	filenames = append(filenames, "_a", "/u", "tUtEt4")
+	cases := []struct {
+		pattern string
+		opts    []fuzzy.Option
+	}{
+		{"ue4", nil},
+		{"AES", nil},
+		{"gfs", nil},
+		{"ue4", []fuzzy.Option{fuzzy.WithAlgorithm(fuzzy.AlgorithmOptimal)}},
+		{"lll", []fuzzy.Option{fuzzy.WithPathMode()}},
+		{"ue4 build", []fuzzy.Option{fuzzy.WithMultiTerm()}},
+		{"a", nil},
+		{"u", []fuzzy.Option{fuzzy.WithPathMode()}},
+		// letters can be both separators and start words
+		{"ue4", []fuzzy.Option{fuzzy.WithSeparators("/-_ .\\lt")}},
+	}
+	for _, c := range cases {
+		m := fuzzy.Compile(c.pattern, c.opts...)
+		for _, match := range m.Find(filenames) {
+			// A positive score at or above the maximum would be clamped to 1.
+			if match.Score > 0 && m.NormalizedScore(match.Score) >= m.NormalizedScore(match.Score+1) {
+				t.Errorf("%q in %q: score %v isn't below the maximum", c.pattern, match.Str, match.Score)
+			}
+		}
+	}
+}
+
+func TestCompileWithMinScore(t *testing.T) {
+	data := []string{"moduleNameResolver.ts", "my name is_Ramsey", "m_n_r", "the manor"}
+	want := fuzzy.Matches{
+		{
+			Str:            "m_n_r",
+			Index:          2,
+			MatchedIndexes: []int{0, 2, 4},
+			Score:          78,
+		},
+		{
+			Str:            "my name is_Ramsey",
+			Index:          1,
+			MatchedIndexes: []int{0, 3, 11},
+			Score:          36,
+		},
+	}
+	got := fuzzy.Compile("mnr", fuzzy.WithMinScore(0.3)).Find(data)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+	if match, ok := fuzzy.Compile("mnr", fuzzy.WithMinScore(0.3)).Match(data[0]); ok {
+		t.Errorf("%q matched with a normalized score below the minimum: %v", data[0], match)
+	}
+}
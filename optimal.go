@@ -0,0 +1,141 @@
+package fuzzy
+
+import (
+	"math"
+	"slices"
+)
+
+// unreachable marks dynamic programming cells that no alignment can reach.
+const unreachable = math.MinInt / 2
+
+// maxOptimalCells caps the size of the table of matchOptimal. Larger alignments are left to
+// matchGreedy, because the table grows with the square of the pattern length.
+const maxOptimalCells = 1 << 20
+
+/*
+matchOptimal finds the alignment of the runes of t in s with the highest score and returns that score along
+with indexes extended by the byte index of every matched rune. indexes must be empty. Nothing is
+added to indexes if s doesn't contain every rune of the pattern in order.
+
+The bonus and penalty rules are the same as for the greedy algorithm. The adjacency bonus of an
+alignment only depends on how many of its matched runes directly follow the previous matched rune,
+so the table tracks the best score for every pattern rune, match string rune and number of
+adjacent matches so far. If that table would exceed maxOptimalCells, s is
+matched by matchGreedy instead.
+*/
+func matchOptimal(s string, t *term, indexes []int, opts *Options, sc *scratch) (int, []int) {
+	runes := t.runes
+	if !containsInOrder(s, t) {
+		return 0, indexes
+	}
+	sc.prepare(s, opts)
+	n, m := len(sc.runes), len(runes)
+	if m*n*m > maxOptimalCells {
+		return matchGreedy(s, t, indexes, opts, sc)
+	}
+
+	// table[(i*n+j)*m+a] is the best score for matching runes[:i+1] with runes[i] at j and a
+	// adjacent matches.
+	sc.table = grow(sc.table, m*n*m)
+	cell := func(i, j, a int) *int { return &sc.table[(i*n+j)*m+a] }
+	for j := 0; j < n; j++ {
+		for a := 0; a < m; a++ {
+			*cell(0, j, a) = unreachable
+		}
+		if t.equal(runes[0], sc.runes[j]) {
+			*cell(0, j, 0) = sc.bonuses[j] + sc.leadingPenalty(j, opts)
+		}
+	}
+	// best[a] is the best score of the previous pattern rune ending two or more runes before j.
+	sc.best = grow(sc.best, m)
+	for i := 1; i < m; i++ {
+		for a := range sc.best {
+			sc.best[a] = unreachable
+		}
+		for j := 0; j < n; j++ {
+			if j >= 2 {
+				for a := 0; a < m; a++ {
+					sc.best[a] = max(sc.best[a], *cell(i-1, j-2, a))
+				}
+			}
+			matches := t.equal(runes[i], sc.runes[j])
+			for a := 0; a < m; a++ {
+				score := unreachable
+				if matches {
+					score = sc.best[a]
+					if a > 0 && j > 0 {
+						score = max(score, *cell(i-1, j-1, a-1))
+					}
+					if score > unreachable {
+						score += sc.bonuses[j]
+					}
+				}
+				*cell(i, j, a) = score
+			}
+		}
+	}
+
+	bestScore, bestJ, bestA := unreachable, -1, -1
+	for j := 0; j < n; j++ {
+		for a := 0; a < m; a++ {
+			if score := *cell(m-1, j, a); score > unreachable {
+				if score += adjacentBonusTotal(a, opts); score > bestScore {
+					bestScore, bestJ, bestA = score, j, a
+				}
+			}
+		}
+	}
+	if bestJ < 0 {
+		return 0, indexes
+	}
+
+	// Walk the table backwards to recover the positions of the best alignment, preferring the
+	// earliest position on ties.
+	indexes = slices.Grow(indexes, m)[:m]
+	j, a := bestJ, bestA
+	for i := m - 1; ; i-- {
+		indexes[i] = sc.offsets[j]
+		if i == 0 {
+			break
+		}
+		prev := *cell(i, j, a) - sc.bonuses[j]
+		next := -1
+		for k := 0; k < j-1; k++ {
+			if *cell(i-1, k, a) == prev {
+				next = k
+				break
+			}
+		}
+		if next < 0 {
+			next, a = j-1, a-1
+		}
+		j = next
+	}
+	sc.recordIndexes(indexes, nil, opts)
+	return bestScore, indexes
+}
+
+// adjacentBonusTotal returns the sum of the adjacency bonuses awarded to count adjacent matches.
+func adjacentBonusTotal(count int, opts *Options) int {
+	var total int
+	for ; count > 0; count-- {
+		// every adjacent match is worth twice the bonuses so far on top of AdjacentMatchBonus
+		total += total*2 + opts.AdjacentMatchBonus
+	}
+	return total
+}
+
+// containsInOrder reports whether s contains every rune of t in order.
+func containsInOrder(s string, t *term) bool {
+	runes := t.runes
+	i := 0
+	for _, r := range s {
+		if i == len(runes) {
+			break
+		}
+		if t.equal(runes[i], r) {
+			i++
+		}
+	}
+	return i == len(runes)
+}
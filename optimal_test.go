@@ -0,0 +1,195 @@
+package fuzzy_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func optimalOptions() fuzzy.Options {
+	opts := fuzzy.DefaultOptions
+	opts.Algorithm = fuzzy.AlgorithmOptimal
+	return opts
+}
+
+func TestFindOptimal(t *testing.T) {
+	cases := []struct {
+		pattern string
+		data    []string
+		matches fuzzy.Matches
+	}{
+		// the greedy algorithm commits to the first "a" as soon as it sees the "b" following it
+		// (a = 20 - 15 leading chars, b = 5) - 4 unmatched chars = 6
+		{
+			"ab", []string{"xab_ab"}, fuzzy.Matches{
+				{
+					Str:            "xab_ab",
+					Index:          0,
+					MatchedIndexes: []int{4, 5},
+					Score:          6,
+				},
+			},
+		},
+		// same result as the greedy algorithm when it finds the best alignment
+		{
+			"mnr", []string{"moduleNameResolver.ts"}, fuzzy.Matches{
+				{
+					Str:            "moduleNameResolver.ts",
+					Index:          0,
+					MatchedIndexes: []int{0, 6, 10},
+					Score:          32,
+				},
+			},
+		},
+		{
+			"aaa", []string{"aaa", "bbb"}, fuzzy.Matches{
+				{
+					Str:            "aaa",
+					Index:          0,
+					MatchedIndexes: []int{0, 1, 2},
+					Score:          30,
+				},
+			},
+		},
+		{
+			"tk", []string{"The Black Knight"}, fuzzy.Matches{
+				{
+					Str:            "The Black Knight",
+					Index:          0,
+					MatchedIndexes: []int{0, 10},
+					Score:          16,
+				},
+			},
+		},
+		{
+			"mmt", []string{"mémeTemps"}, fuzzy.Matches{
+				{
+					Str:            "mémeTemps",
+					Index:          0,
+					MatchedIndexes: []int{0, 3, 5},
+					Score:          23,
+				},
+			},
+		},
+		{
+			"cats", []string{"cat"}, nil,
+		},
+	}
+	for _, c := range cases {
+		matches := fuzzy.FindWithOptions(c.pattern, c.data, optimalOptions())
+		if diff := pretty.Compare(c.matches, matches); diff != "" {
+			t.Errorf("%v: %v", c.pattern, diff)
+		}
+	}
+}
+
+func TestFindOptimalPaths(t *testing.T) {
+	cases := []struct {
+		str          string
+		greedy       []int
+		greedyScore  int
+		optimal      []int
+		optimalScore int
+	}{
+		// the greedy algorithm already finds the best alignment: (f = 10, b = 20) - 12 unmatched chars = 18
+		{"foo/bar/foobar", []int{0, 4}, 18, []int{0, 4}, 18},
+		// the greedy algorithm commits to the f of xfb since a b follows it, which costs the
+		// separator bonus of the other f: (f = 20 - 15 leading chars, b = 20) - 9 unmatched chars = 16
+		{"xfb/foo/bar", []int{1, 8}, 6, []int{4, 8}, 16},
+	}
+	for _, c := range cases {
+		greedy, _ := fuzzy.Compile("fb").Match(c.str)
+		optimal, _ := fuzzy.Compile("fb", fuzzy.WithAlgorithm(fuzzy.AlgorithmOptimal)).Match(c.str)
+		if diff := pretty.Compare(c.greedy, greedy.MatchedIndexes); diff != "" || greedy.Score != c.greedyScore {
+			t.Errorf("%q: got greedy score %v; expected %v\n%v", c.str, greedy.Score, c.greedyScore, diff)
+		}
+		if diff := pretty.Compare(c.optimal, optimal.MatchedIndexes); diff != "" || optimal.Score != c.optimalScore {
+			t.Errorf("%q: got optimal score %v; expected %v\n%v", c.str, optimal.Score, c.optimalScore, diff)
+		}
+	}
+}
+
+func TestFindOptimalNeverScoresLower(t *testing.T) {
+	bytes, err := os.ReadFile("testdata/ue4_filenames.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filenames := strings.Split(string(bytes), "\n")
+	for _, pattern := range []string{"ue4", "lll", "aes", "gmode"} {
+		greedy := fuzzy.FindNoSort(pattern, filenames)
+		optimal := fuzzy.FindWithOptions(pattern, filenames, optimalOptions())
+		if len(greedy) != len(optimal) {
+			t.Fatalf("%v: got %v optimal Matches; expected %v", pattern, len(optimal), len(greedy))
+		}
+		scores := make(map[int]int, len(greedy))
+		for _, m := range greedy {
+			scores[m.Index] = m.Score
+		}
+		for _, m := range optimal {
+			if m.Score < scores[m.Index] {
+				t.Errorf("%v: optimal score %v of %q is lower than greedy score %v", pattern, m.Score, m.Str, scores[m.Index])
+			}
+		}
+	}
+}
+
+func TestMatchOptimalLongString(t *testing.T) {
+	pattern, s := longOptimalInput()
+	greedy, ok := fuzzy.Compile(pattern).Match(s)
+	if !ok {
+		t.Fatalf("%q doesn't match", pattern)
+	}
+	// the alignment table would be too large, so the string is aligned greedily
+	optimal, _ := fuzzy.Compile(pattern, fuzzy.WithAlgorithm(fuzzy.AlgorithmOptimal)).Match(s)
+	if diff := pretty.Compare(greedy, optimal); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+// longOptimalInput returns a 60 rune pattern and a string of about 50000 bytes that contains it.
+func longOptimalInput() (string, string) {
+	pattern := strings.Repeat("abcdef", 10)
+	return pattern, strings.Repeat("xaybzcwdveuf", 50000/12) + "xx"
+}
+
+func BenchmarkFindOptimal(b *testing.B) {
+	opts := optimalOptions()
+
+	b.Run("with unreal 4 (~16K files)", func(b *testing.B) {
+		bytes, err := os.ReadFile("testdata/ue4_filenames.txt")
+		if err != nil {
+			b.Fatal(err)
+		}
+		filenames := strings.Split(string(bytes), "\n")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			fuzzy.FindWithOptions("lll", filenames, opts)
+		}
+	})
+
+	b.Run("with linux kernel (~60K files)", func(b *testing.B) {
+		bytes, err := os.ReadFile("testdata/linux_filenames.txt")
+		if err != nil {
+			b.Fatal(err)
+		}
+		filenames := strings.Split(string(bytes), "\n")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			fuzzy.FindWithOptions("alsa", filenames, opts)
+		}
+	})
+}
+
+func BenchmarkMatchOptimalLongString(b *testing.B) {
+	pattern, s := longOptimalInput()
+	m := fuzzy.Compile(pattern, fuzzy.WithAlgorithm(fuzzy.AlgorithmOptimal))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Match(s)
+	}
+}
@@ -0,0 +1,152 @@
+package fuzzy
+
+const (
+	firstCharMatchBonus            = 10
+	matchFollowingSeparatorBonus   = 20
+	camelCaseMatchBonus            = 20
+	adjacentMatchBonus             = 5
+	acronymMatchBonus              = 30
+	unmatchedLeadingCharPenalty    = -5
+	maxUnmatchedLeadingCharPenalty = -15
+	unmatchedCharPenalty           = -1
+	typoPenalty                    = -20
+	basenameMatchBonus             = 10
+	fullBasenameMatchBonus         = 30
+	pathDepthPenalty               = -2
+	adjacentSegmentBonus           = 10
+)
+
+// Algorithm selects how a pattern is aligned against a match string.
+type Algorithm int
+
+const (
+	// AlgorithmGreedy aligns the pattern in a single forward pass. It is fast and picks the best
+	// position for every pattern character that it can see ahead of the next one.
+	AlgorithmGreedy Algorithm = iota
+	// AlgorithmOptimal computes the best-scoring alignment of the pattern using dynamic
+	// programming. It is considerably slower than AlgorithmGreedy. Its table grows with the length
+	// of the string times the square of the length of the pattern, so strings whose table would
+	// exceed about a million cells are aligned greedily instead.
+	AlgorithmOptimal
+)
+
+// CaseMode determines whether matching is case-sensitive.
+type CaseMode int
+
+const (
+	// CaseInsensitive matches runes regardless of their case.
+	CaseInsensitive CaseMode = iota
+	// CaseSensitive only matches runes of the same case.
+	CaseSensitive
+	// CaseSmart matches case-sensitively if the pattern contains an upper case rune and
+	// case-insensitively otherwise. Every term of a pattern split into terms is considered on its
+	// own.
+	CaseSmart
+)
+
+// Options holds the weights used to score a match and controls how matches are found. Bonuses are
+// usually positive and penalties usually negative, but any value is accepted. Start from
+// DefaultOptions and adjust the fields you care about.
+type Options struct {
+	// Applied when the first character in the pattern matches the first character in the match string.
+	FirstCharMatchBonus int
+	// Applied when the matched character follows a separator such as an underscore character.
+	MatchFollowingSeparatorBonus int
+	// The runes that separate words, such as the underscores of snake_case. Matching the rune after
+	// a separator earns MatchFollowingSeparatorBonus. Defaults to "/-_ .\\". Add ":" for symbols
+	// like "pkg.Type:Method" and C++ scopes, or remove "." to treat version strings as one word.
+	Separators string
+	// Applied when the matched character starts a word without following a separator: the N of
+	// fileName, the S of HTTPServer, the 8 and D of utf8Decode, or a letter of another script than
+	// the letter before it.
+	CamelCaseMatchBonus int
+	// Applied when the pattern is made of exactly the initials of the words in the match string, such
+	// as "gfs" for "GetFileSize" or "get_file_size". Words are separated like for the separator and
+	// camel case bonuses.
+	AcronymMatchBonus int
+	// Applied when the matched character is adjacent to a previous match. The bonus grows with
+	// every adjacent match.
+	AdjacentMatchBonus int
+	// Applied for every character up to the first match.
+	UnmatchedLeadingCharPenalty int
+	// Caps the total leading character penalty.
+	MaxUnmatchedLeadingCharPenalty int
+	// Applied for every character in the match string that wasn't matched.
+	UnmatchedCharPenalty int
+	// The algorithm used to align the pattern. Defaults to AlgorithmGreedy.
+	Algorithm Algorithm
+	// How the case of runes is compared. Defaults to CaseInsensitive. The case of the match string
+	// always determines the camel case bonus.
+	CaseMode CaseMode
+	// The number of typos a fuzzy match may contain. A typo is a pattern rune that is substituted by
+	// another rune, two adjacent pattern runes that are transposed, or a pattern rune that is
+	// missing. The typos of all terms of a pattern count together. Terms are only matched with typos
+	// if they don't match without, and at least one of their runes must match. Runes matched on
+	// either side of a typo don't earn the adjacency bonus. Defaults to 0.
+	MaxTypos int
+	// Applied for every typo in a match.
+	TypoPenalty int
+	// Whether match strings are file paths. Paths are split into segments by slashes and
+	// backslashes, and the last segment is the basename.
+	PathMode bool
+	// Applied in path mode for every matched character in the basename.
+	BasenameMatchBonus int
+	// Applied in path mode when every character of the basename up to its extension is matched.
+	FullBasenameMatchBonus int
+	// Applied in path mode for every directory that contains the basename.
+	PathDepthPenalty int
+	// Whether fuzzy terms containing slashes or backslashes are split into segments that must match
+	// successive segments of a path in order, so that "s/c/main" matches "src/cmd/main.go". Path
+	// segments may be skipped between matched ones.
+	PathSegments bool
+	// Applied in path segment mode for every pattern segment matched in the path segment right
+	// after the one matched by the previous pattern segment.
+	AdjacentSegmentBonus int
+	// Whether diacritics are ignored, so that "cafe" matches "café". Both the pattern and the match
+	// string are decomposed and stripped of combining marks. MatchedIndexes still refer to the
+	// original match string.
+	IgnoreDiacritics bool
+	// Whether the pattern is split into terms separated by whitespace. A string matches if it
+	// matches every term, in any order. The score of a match is the sum of the scores of its terms
+	// and MatchedIndexes holds the indexes matched by any term.
+	MultiTerm bool
+	// The lowest normalized score of a match, as returned by Matcher.NormalizedScore. Strings that
+	// score lower don't match. Defaults to 0, which keeps every match.
+	MinScore float64
+	// Orders matches with equal scores, trying one TieBreaker after the other. Matches that are
+	// still tied keep their input order.
+	TieBreakers []TieBreaker
+	// Replaces the order of sorted results if set. It returns a negative number if a ranks before b
+	// and a positive number if it ranks after b, and is used instead of Score and TieBreakers.
+	// Match.Index is the position of the string in the Source, even while several goroutines match
+	// it, so other signals about a string can be looked up by it. Matches it considers equal keep
+	// their input order.
+	Compare func(a, b Match) int
+	// Adds a boost to the score of every match if set, such as a History of the strings the user
+	// picked. The boost isn't taken into account by MinScore.
+	Booster Booster
+	// The number of goroutines used to match a Source. Values below 2 match sequentially. The
+	// Source must be safe for concurrent calls to String when matching concurrently.
+	Concurrency int
+
+	// The lookup for Separators, built when a pattern is compiled.
+	separators separatorSet
+}
+
+// DefaultOptions holds the options used by Find and friends.
+var DefaultOptions = Options{
+	FirstCharMatchBonus:            firstCharMatchBonus,
+	MatchFollowingSeparatorBonus:   matchFollowingSeparatorBonus,
+	Separators:                     defaultSeparators,
+	CamelCaseMatchBonus:            camelCaseMatchBonus,
+	AdjacentMatchBonus:             adjacentMatchBonus,
+	AcronymMatchBonus:              acronymMatchBonus,
+	UnmatchedLeadingCharPenalty:    unmatchedLeadingCharPenalty,
+	MaxUnmatchedLeadingCharPenalty: maxUnmatchedLeadingCharPenalty,
+	UnmatchedCharPenalty:           unmatchedCharPenalty,
+	TypoPenalty:                    typoPenalty,
+	BasenameMatchBonus:             basenameMatchBonus,
+	FullBasenameMatchBonus:         fullBasenameMatchBonus,
+	PathDepthPenalty:               pathDepthPenalty,
+	AdjacentSegmentBonus:           adjacentSegmentBonus,
+}
@@ -0,0 +1,64 @@
+package fuzzy_test
+
+import (
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestFindWithDefaultOptions(t *testing.T) {
+	data := []string{"moduleNameResolver.ts", "my name is_Ramsey", "The Black Knight"}
+	want := fuzzy.Find("mnr", data)
+	got := fuzzy.FindWithOptions("mnr", data, fuzzy.DefaultOptions)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestFindWithOptions(t *testing.T) {
+	opts := fuzzy.DefaultOptions
+	opts.CamelCaseMatchBonus = 100
+	opts.MatchFollowingSeparatorBonus = 0
+	// (m = 10, n = 100, r = 100) - 18 unmatched chars = 192
+	// (m = 10, n = 0, r = 0) - 14 unmatched chars = -4
+	want := fuzzy.Matches{
+		{
+			Str:            "moduleNameResolver.ts",
+			Index:          0,
+			MatchedIndexes: []int{0, 6, 10},
+			Score:          192,
+		},
+		{
+			Str:            "my name is_Ramsey",
+			Index:          1,
+			MatchedIndexes: []int{0, 3, 11},
+			Score:          -4,
+		},
+	}
+	got := fuzzy.FindWithOptions("mnr", []string{"moduleNameResolver.ts", "my name is_Ramsey"}, opts)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestFindFromWithOptionsPenalties(t *testing.T) {
+	opts := fuzzy.DefaultOptions
+	opts.UnmatchedLeadingCharPenalty = -1
+	opts.MaxUnmatchedLeadingCharPenalty = -100
+	opts.UnmatchedCharPenalty = 0
+	// a = 0 after 3 leading chars, b = 5 adjacent
+	want := fuzzy.Matches{
+		{
+			Str:            "xyzab",
+			Index:          0,
+			MatchedIndexes: []int{3, 4},
+			Score:          2,
+		},
+	}
+	got := fuzzy.FindFromWithOptions("ab", employees{{name: "xyzab"}}, opts)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
@@ -0,0 +1,67 @@
+package fuzzy
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// minShardLen is the smallest number of strings worth handing to a goroutine of its own.
+const minShardLen = 1024
+
+/*
+FindParallel is an alternative implementation of Find that matches data
+on all available CPU cores. The results are identical to those of Find.
+*/
+func FindParallel(pattern string, data []string) Matches {
+	return FindFromParallel(pattern, stringSource(data))
+}
+
+/*
+FindFromParallel is an alternative implementation of FindFrom that matches data
+on all available CPU cores. The results are identical to those of FindFrom.
+data must be safe for concurrent calls to String.
+*/
+func FindFromParallel(pattern string, data Source) Matches {
+	return Compile(pattern, WithConcurrency(runtime.GOMAXPROCS(0))).FindFrom(data)
+}
+
+// findFromNoSort matches data using up to Options.Concurrency goroutines, in the order of data.
+func (m *Matcher) findFromNoSort(data Source) Matches {
+	return m.findSharded(data, m.findFromIterNoSort)
+}
+
+// findSharded calls find for contiguous ranges of data using up to Options.Concurrency goroutines,
+// and concatenates the results in order. find is passed the index of the first string of its range,
+// which it must use as the index of the first string of it, so that comparisons see the same
+// indexes as when calling find for all of data.
+func (m *Matcher) findSharded(data Source, find func(it iter.Seq[string], offset int) Matches) Matches {
+	n := min(m.opts.Concurrency, data.Len()/minShardLen)
+	if n < 2 {
+		return find(iterFromSource(data), 0)
+	}
+	shardLen := (data.Len() + n - 1) / n
+	shards := make([]Matches, n)
+	var wg sync.WaitGroup
+	for k := range shards {
+		lo, hi := k*shardLen, min((k+1)*shardLen, data.Len())
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shards[k] = find(iterFromRange(data, lo, hi), lo)
+		}()
+	}
+	wg.Wait()
+	var total int
+	for _, shard := range shards {
+		total += len(shard)
+	}
+	if total == 0 {
+		return nil
+	}
+	matches := make(Matches, 0, total)
+	for _, shard := range shards {
+		matches = append(matches, shard...)
+	}
+	return matches
+}
@@ -0,0 +1,69 @@
+package fuzzy_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestFindParallel(t *testing.T) {
+	for _, file := range []string{"testdata/ue4_filenames.txt", "testdata/linux_filenames.txt"} {
+		bytes, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		filenames := strings.Split(string(bytes), "\n")
+		for _, pattern := range []string{"ue4", "lll", "make", "alsa", "c"} {
+			want := fuzzy.Find(pattern, filenames)
+			if diff := pretty.Compare(want, fuzzy.FindParallel(pattern, filenames)); diff != "" {
+				t.Errorf("%v in %v: %v", pattern, file, diff)
+			}
+			for _, n := range []int{2, 3, 8} {
+				got := fuzzy.Compile(pattern, fuzzy.WithConcurrency(n)).Find(filenames)
+				if diff := pretty.Compare(want, got); diff != "" {
+					t.Errorf("%v in %v with %v goroutines: %v", pattern, file, n, diff)
+				}
+			}
+		}
+	}
+}
+
+func TestFindFromParallelWithSmallSource(t *testing.T) {
+	emps := employees{{name: "Alice"}, {name: "Bob"}, {name: "Allie"}}
+	if diff := pretty.Compare(fuzzy.FindFrom("al", emps), fuzzy.FindFromParallel("al", emps)); diff != "" {
+		t.Errorf("%v", diff)
+	}
+	if got := fuzzy.FindFromParallel("xyz", emps); got != nil {
+		t.Errorf("got %v; expected no Matches", got)
+	}
+}
+
+func BenchmarkFindParallel(b *testing.B) {
+	b.Run("with unreal 4 (~16K files)", func(b *testing.B) {
+		bytes, err := os.ReadFile("testdata/ue4_filenames.txt")
+		if err != nil {
+			b.Fatal(err)
+		}
+		filenames := strings.Split(string(bytes), "\n")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			fuzzy.FindParallel("lll", filenames)
+		}
+	})
+
+	b.Run("with linux kernel (~60K files)", func(b *testing.B) {
+		bytes, err := os.ReadFile("testdata/linux_filenames.txt")
+		if err != nil {
+			b.Fatal(err)
+		}
+		filenames := strings.Split(string(bytes), "\n")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			fuzzy.FindParallel("alsa", filenames)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package fuzzy
+
+import "strings"
+
+// pathSeparators separate the segments of a path.
+const pathSeparators = "/\\"
+
+/*
+matchPath applies path mode to a match of s with the given score at indexes,
+which already includes the unmatched character penalty. The pattern is also
+matched against the basename on its own, scored as if the directory wasn't
+there, so that a match in the directory doesn't hide a better one in the
+basename. The better of both is returned along with its indexes.
+
+Prefix, equal and exclusion terms still apply to the whole path, because the
+basename is only tried if the whole path matches.
+*/
+func (m *Matcher) matchPath(s string, score int, indexes []int, sc *scratch) (int, []int) {
+	score += pathBonus(s, indexes, &m.opts, sc)
+	base := strings.LastIndexAny(s, pathSeparators) + 1
+	if base == 0 {
+		return score, indexes
+	}
+	// The scratch space was prepared for the whole path.
+	sc.reset()
+	n, mark := len(indexes), len(sc.events)
+	baseScore, baseIndexes, ok := m.matchGroups(s[base:], indexes[n:], sc)
+	if !ok {
+		sc.events = sc.events[:mark]
+		return score, indexes
+	}
+	for i := range baseIndexes {
+		baseIndexes[i] += base
+	}
+	sc.shiftEvents(mark, base)
+	baseScore += (len(s) - len(baseIndexes)) * m.opts.UnmatchedCharPenalty
+	sc.record(eventUnmatched, -1, (len(s)-len(baseIndexes))*m.opts.UnmatchedCharPenalty)
+	baseScore += pathBonus(s, baseIndexes, &m.opts, sc)
+	if baseScore <= score {
+		sc.events = sc.events[:mark]
+		return score, indexes
+	}
+	// Everything recorded before mark belongs to the whole path.
+	sc.discardEvents(0, mark)
+	return baseScore, append(indexes[:0], baseIndexes...)
+}
+
+/*
+pathBonus returns the bonuses and penalties of path mode for a match of s
+at indexes, which must be in ascending order:
+
+* BasenameMatchBonus for every index in the basename.
+
+* FullBasenameMatchBonus if every character of the basename up to its
+extension is matched. A leading dot doesn't start an extension.
+
+* PathDepthPenalty for every directory of the path.
+*/
+func pathBonus(s string, indexes []int, opts *Options, sc *scratch) int {
+	base := strings.LastIndexAny(s, pathSeparators) + 1
+	score := strings.Count(s[:base], "/") + strings.Count(s[:base], `\`)
+	score *= opts.PathDepthPenalty
+	sc.record(eventPathDepth, -1, score)
+	matched := 0
+	for _, j := range indexes {
+		if j >= base {
+			matched++
+		}
+	}
+	score += matched * opts.BasenameMatchBonus
+	sc.record(eventBasename, -1, matched*opts.BasenameMatchBonus)
+	end := len(s)
+	if dot := strings.LastIndexByte(s[base:], '.'); dot > 0 {
+		end = base + dot
+	}
+	// Every byte of the name must be covered by a rune starting at a matched index.
+	if end > base && fullyMatched(s, base, end, indexes) {
+		score += opts.FullBasenameMatchBonus
+		sc.record(eventFullBasename, -1, opts.FullBasenameMatchBonus)
+	}
+	return score
+}
+
+// fullyMatched reports whether every rune of s[start:end] starts at one of indexes.
+func fullyMatched(s string, start, end int, indexes []int) bool {
+	i := 0
+	for i < len(indexes) && indexes[i] < start {
+		i++
+	}
+	for j := range s[start:end] {
+		if i == len(indexes) || indexes[i] != start+j {
+			return false
+		}
+		i++
+	}
+	return true
+}
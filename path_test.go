@@ -0,0 +1,138 @@
+package fuzzy_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestCompileWithPathMode(t *testing.T) {
+	data := []string{
+		"maintenance/foo.c",
+		"cmd/main.go",
+		"internal/domain/user.go",
+		"main_test.go",
+		"a/b/c/main.go",
+		`win\cmd\main.go`,
+	}
+	// Scored in the basename: (m = 10, ain = 65) - 7 unmatched chars + 4 * 10 basename chars
+	// + 30 full basename - 2 for 1 directory = 136
+	want := fuzzy.Matches{
+		{
+			Str:            "cmd/main.go",
+			Index:          1,
+			MatchedIndexes: []int{4, 5, 6, 7},
+			Score:          136,
+		},
+		{
+			Str:            "a/b/c/main.go",
+			Index:          4,
+			MatchedIndexes: []int{6, 7, 8, 9},
+			Score:          130,
+		},
+		{
+			Str:            `win\cmd\main.go`,
+			Index:          5,
+			MatchedIndexes: []int{8, 9, 10, 11},
+			Score:          130,
+		},
+		{
+			Str:            "main_test.go",
+			Index:          3,
+			MatchedIndexes: []int{0, 1, 2, 3},
+			Score:          107,
+		},
+		{
+			Str:            "maintenance/foo.c",
+			Index:          0,
+			MatchedIndexes: []int{0, 1, 2, 3},
+			Score:          60,
+		},
+		{
+			Str:            "internal/domain/user.go",
+			Index:          2,
+			MatchedIndexes: []int{11, 12, 13, 14},
+			Score:          27,
+		},
+	}
+	got := fuzzy.Compile("main", fuzzy.WithPathMode()).Find(data)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestCompileWithPathModeFullBasename(t *testing.T) {
+	cases := []struct {
+		pattern string
+		str     string
+		full    bool
+	}{
+		{"main", "cmd/main.go", true},
+		{"main", "cmd/main", true},
+		{"main", "cmd/mains.go", false},
+		{"bashrc", "home/.bashrc", false},
+		{".bashrc", "home/.bashrc", true},
+		{"tar", "dist/app.tar.gz", false},
+		{"app.tar", "dist/app.tar.gz", true},
+	}
+	opts := fuzzy.DefaultOptions
+	opts.FullBasenameMatchBonus = 0
+	for _, c := range cases {
+		without, _ := fuzzy.Compile(c.pattern, fuzzy.WithOptions(opts), fuzzy.WithPathMode()).Match(c.str)
+		with, ok := fuzzy.Compile(c.pattern, fuzzy.WithPathMode()).Match(c.str)
+		if !ok {
+			t.Errorf("%q didn't match %q", c.pattern, c.str)
+			continue
+		}
+		if full := with.Score-without.Score == fuzzy.DefaultOptions.FullBasenameMatchBonus; full != c.full {
+			t.Errorf("%q in %q: got full basename bonus %v; expected %v", c.pattern, c.str, full, c.full)
+		}
+	}
+}
+
+func TestCompileWithPathModeRealworldData(t *testing.T) {
+	cases := []struct {
+		file      string
+		dir       string
+		pattern   string
+		filenames []string
+	}{
+		{"testdata/ue4_filenames.txt", "Engine/Source/", "ue4", []string{"UE4Build.cs", "UE4Game.cpp", "UE4BuildUtils.cs", "UE4Game.Build.cs"}},
+		{"testdata/ue4_filenames.txt", "Engine/Source/", "lll", []string{"LODLevelItem.h", "LODLevelItem.cpp", "LogFileLogger.cs"}},
+		{"testdata/ue4_filenames.txt", "Engine/Source/", "aes", []string{"AES.h", "AES.cpp", "ActiveSound.h"}},
+		{"testdata/linux_filenames.txt", "sound/pci/", "alsa", []string{"alsa.c", "alsa.h", "aw2-alsa.c", "ivtv-alsa.h"}},
+		{"testdata/linux_filenames.txt", "scripts/", "make", []string{"make", "makelst", "Makefile", "Makefile"}},
+	}
+	for _, c := range cases {
+		bytes, err := os.ReadFile(c.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		filenames := strings.Split(string(bytes), "\n")
+		m := fuzzy.Compile(c.pattern, fuzzy.WithPathMode())
+		// basenames rank like they do without path mode
+		var found []string
+		for _, match := range m.Find(filenames)[:len(c.filenames)] {
+			found = append(found, match.Str)
+		}
+		if diff := pretty.Compare(c.filenames, found); diff != "" {
+			t.Errorf("%v: %v", c.pattern, diff)
+		}
+		// and so do the same files in a directory, even though the directory can be matched too
+		paths := make([]string, len(filenames))
+		for i, filename := range filenames {
+			paths[i] = c.dir + filename
+		}
+		found = found[:0]
+		for _, match := range m.Find(paths)[:len(c.filenames)] {
+			found = append(found, strings.TrimPrefix(match.Str, c.dir))
+		}
+		if diff := pretty.Compare(c.filenames, found); diff != "" {
+			t.Errorf("%v in %v: %v", c.pattern, c.dir, diff)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package fuzzy
+
+import "strings"
+
+// termKind determines how a term is matched.
+type termKind int
+
+const (
+	// termFuzzy matches the runes of the term in order, anywhere in the string.
+	termFuzzy termKind = iota
+	// termExact matches the runes of the term as a contiguous substring.
+	termExact
+	// termPrefix matches the runes of the term at the start of the string.
+	termPrefix
+	// termSuffix matches the runes of the term at the end of the string.
+	termSuffix
+	// termEqual matches the runes of the term against the whole string.
+	termEqual
+)
+
+// term is a single unit of a pattern.
+type term struct {
+	kind  termKind
+	runes []rune
+	// A string matches an inverse term if the term doesn't match it.
+	inverse bool
+	// Whether runes are compared case-sensitively.
+	caseSensitive bool
+	// The terms matched against successive path segments in path segment mode, if the runes
+	// contain path separators.
+	segments []term
+}
+
+// equal reports whether the rune p of the term matches the rune r of a string.
+func (t *term) equal(p, r rune) bool {
+	if t.caseSensitive {
+		return p == r
+	}
+	return equalFold(p, r)
+}
+
+/*
+ParseQuery compiles a query written in an extended syntax in the style of fzf.
+The query is split into terms separated by whitespace. A string matches if it
+matches every term, in any order, as described for Options.MultiTerm. A term
+is fuzzy matched like a pattern passed to Compile unless it has one of the
+following forms:
+
+* 'exact matches "exact" as a contiguous substring.
+
+* ^prefix matches "prefix" at the start of the string.
+
+* suffix$ matches "suffix" at the end of the string.
+
+* ^whole$ matches "whole" against the entire string.
+
+* !exclude matches strings that don't contain "exclude". It may be combined
+with ^ and $, e.g. !^prefix excludes strings that start with "prefix".
+
+Exact, prefix and suffix terms are scored like a fuzzy match of the same runes,
+and their runes are reported in MatchedIndexes. Strings matching an exclusion
+only score penalties for their unmatched characters and have no MatchedIndexes.
+A term that is empty once its operators are removed, such as a lone ^, is fuzzy
+matched literally.
+
+Terms separated by a | surrounded by whitespace are alternatives. A string
+matches a group of alternatives if it matches any of them, and the score and
+MatchedIndexes of the best scoring alternative are reported. | binds tighter
+than whitespace, so "^core go$ | rb$" matches strings that start with "core"
+and end with either "go" or "rb". A | at the start or end of the query or next
+to another | is ignored.
+*/
+func ParseQuery(query string, opts ...Option) *Matcher {
+	var groups [][]term
+	var or bool
+	for _, field := range strings.Fields(query) {
+		if field == "|" {
+			or = true
+			continue
+		}
+		if or && len(groups) > 0 {
+			groups[len(groups)-1] = append(groups[len(groups)-1], parseTerm(field))
+		} else {
+			groups = append(groups, []term{parseTerm(field)})
+		}
+		or = false
+	}
+	return newMatcher(query, groups, applyOptions(opts))
+}
+
+/*
+FindQuery is an alternative implementation of Find that looks up a query
+written in the extended syntax described for ParseQuery.
+*/
+func FindQuery(query string, data []string) Matches {
+	return ParseQuery(query).Find(data)
+}
+
+/*
+FindFromQuery is an alternative implementation of FindQuery using a Source
+instead of a list of strings.
+*/
+func FindFromQuery(query string, data Source) Matches {
+	return ParseQuery(query).FindFrom(data)
+}
+
+func parseTerm(text string) term {
+	t := term{kind: termFuzzy}
+	rest := text
+	if strings.HasPrefix(rest, "!") {
+		t.inverse = true
+		t.kind = termExact
+		rest = rest[1:]
+	}
+	switch {
+	case strings.HasPrefix(rest, "'"):
+		t.kind = termExact
+		rest = rest[1:]
+	case strings.HasPrefix(rest, "^") && len(rest) >= 2 && strings.HasSuffix(rest, "$"):
+		t.kind = termEqual
+		rest = rest[1 : len(rest)-1]
+	case strings.HasPrefix(rest, "^"):
+		t.kind = termPrefix
+		rest = rest[1:]
+	case strings.HasSuffix(rest, "$"):
+		t.kind = termSuffix
+		rest = rest[:len(rest)-1]
+	}
+	if rest == "" {
+		return term{kind: termFuzzy, runes: []rune(text)}
+	}
+	t.runes = []rune(rest)
+	return t
+}
+
+// matchTerm matches t against s and returns its score along with indexes extended by the byte
+// index of every matched rune. indexes must be empty.
+func (m *Matcher) matchTerm(t *term, s string, indexes []int, sc *scratch) (int, []int, bool) {
+	var score int
+	var ok bool
+	mark := len(sc.events)
+	sc.typos = 0
+	switch {
+	case t.segments != nil:
+		score, indexes, ok = m.matchSegments(t, s, indexes, sc)
+	case t.kind != termFuzzy:
+		score, indexes = matchContiguous(t, s, indexes, &m.opts, sc)
+		ok = len(indexes) == len(t.runes)
+	default:
+		score, indexes, ok = m.matchFuzzy(t, s, indexes, sc)
+	}
+	if t.inverse {
+		sc.events = sc.events[:mark]
+		sc.typos = 0
+		return 0, indexes[:0], !ok
+	}
+	return score, indexes, ok
+}
+
+// matchFuzzy matches the fuzzy term t against s like matchTerm, falling back to a match with typos
+// if they are allowed.
+func (m *Matcher) matchFuzzy(t *term, s string, indexes []int, sc *scratch) (int, []int, bool) {
+	var score int
+	mark := len(sc.events)
+	sc.typos = 0
+	switch {
+	case m.opts.Algorithm == AlgorithmOptimal:
+		score, indexes = matchOptimal(s, t, indexes, &m.opts, sc)
+	case containsInOrder(s, t):
+		score, indexes = matchGreedy(s, t, indexes, &m.opts, sc)
+	}
+	if len(indexes) == len(t.runes) {
+		if m.opts.AcronymMatchBonus != 0 {
+			n, acronymMark := len(indexes), len(sc.events)
+			if acronymScore, acronymIndexes, ok := matchAcronym(s, t, indexes[n:], &m.opts, sc); ok {
+				if acronymScore > score {
+					sc.discardEvents(mark, acronymMark)
+					return acronymScore, append(indexes[:0], acronymIndexes...), true
+				}
+				sc.events = sc.events[:acronymMark]
+			}
+		}
+		return score, indexes, true
+	}
+	sc.events = sc.events[:mark]
+	if m.opts.MaxTypos > 0 {
+		return matchTypos(s, t, indexes[:0], &m.opts, sc)
+	}
+	return score, indexes, false
+}
+
+// matchContiguous finds the best-scoring occurrence of the runes of t in s at the positions
+// allowed by its kind. Nothing is added to indexes if there is no such occurrence.
+func matchContiguous(t *term, s string, indexes []int, opts *Options, sc *scratch) (int, []int) {
+	sc.prepare(s, opts)
+	n, l := len(sc.runes), len(t.runes)
+	if l > n {
+		return 0, indexes
+	}
+	first, last := 0, n-l
+	switch t.kind {
+	case termPrefix:
+		last = 0
+	case termSuffix:
+		first = n - l
+	case termEqual:
+		if l != n {
+			return 0, indexes
+		}
+	}
+	bestScore, bestJ := unreachable, -1
+	for j := first; j <= last; j++ {
+		if !t.hasRunesAt(sc.runes, j) {
+			continue
+		}
+		score := sc.leadingPenalty(j, opts) + adjacentBonusTotal(l-1, opts)
+		for _, bonus := range sc.bonuses[j : j+l] {
+			score += bonus
+		}
+		if score > bestScore {
+			bestScore, bestJ = score, j
+		}
+	}
+	if bestJ < 0 {
+		return 0, indexes
+	}
+	start := len(indexes)
+	indexes = append(indexes, sc.offsets[bestJ:bestJ+l]...)
+	sc.recordIndexes(indexes[start:], nil, opts)
+	return bestScore, indexes
+}
+
+// hasRunesAt reports whether the runes of t occur in s at position j.
+func (t *term) hasRunesAt(s []rune, j int) bool {
+	for i, r := range t.runes {
+		if !t.equal(r, s[j+i]) {
+			return false
+		}
+	}
+	return true
+}
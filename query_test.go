@@ -0,0 +1,236 @@
+package fuzzy_test
+
+import (
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestParseQuery(t *testing.T) {
+	cases := []struct {
+		query   string
+		data    []string
+		matches fuzzy.Matches
+	}{
+		// fuzzy terms match like Find
+		{
+			"mnr", []string{"moduleNameResolver.ts"}, fuzzy.Matches{
+				{
+					Str:            "moduleNameResolver.ts",
+					Index:          0,
+					MatchedIndexes: []int{0, 6, 10},
+					Score:          32,
+				},
+			},
+		},
+		// exact match, (o = 0 - 5 leading chars, d = 5, u = 15) - 18 unmatched chars = -3
+		{
+			"'odu", []string{"moduleNameResolver.ts", "mode_du"}, fuzzy.Matches{
+				{
+					Str:            "moduleNameResolver.ts",
+					Index:          0,
+					MatchedIndexes: []int{1, 2, 3},
+					Score:          -3,
+				},
+			},
+		},
+		// prefix match, (m = 10, o = 5, d = 15) - 18 unmatched chars = 12
+		{
+			"^mod", []string{"a_module", "moduleNameResolver.ts"}, fuzzy.Matches{
+				{
+					Str:            "moduleNameResolver.ts",
+					Index:          1,
+					MatchedIndexes: []int{0, 1, 2},
+					Score:          12,
+				},
+			},
+		},
+		// suffix match, (t = 20 - 15 leading chars, s = 5) - 19 unmatched chars = -9
+		{
+			"ts$", []string{"moduleNameResolver.ts", "ts.go"}, fuzzy.Matches{
+				{
+					Str:            "moduleNameResolver.ts",
+					Index:          0,
+					MatchedIndexes: []int{19, 20},
+					Score:          -9,
+				},
+			},
+		},
+		// equal match, c = 10, a = 5, t = 15
+		{
+			"^cat$", []string{"cats", "cat", "Cat"}, fuzzy.Matches{
+				{
+					Str:            "cat",
+					Index:          1,
+					MatchedIndexes: []int{0, 1, 2},
+					Score:          30,
+				},
+				{
+					Str:            "Cat",
+					Index:          2,
+					MatchedIndexes: []int{0, 1, 2},
+					Score:          30,
+				},
+			},
+		},
+		// exclusion only scores unmatched chars
+		{
+			"!test", []string{"main_test.go", "main.go"}, fuzzy.Matches{
+				{
+					Str:            "main.go",
+					Index:          1,
+					MatchedIndexes: []int{},
+					Score:          -7,
+				},
+			},
+		},
+		{
+			"!^main", []string{"main.go", "cmd_main.go"}, fuzzy.Matches{
+				{
+					Str:            "cmd_main.go",
+					Index:          1,
+					MatchedIndexes: []int{},
+					Score:          -11,
+				},
+			},
+		},
+		{
+			"!.go$", []string{"main.go", "go.mod"}, fuzzy.Matches{
+				{
+					Str:            "go.mod",
+					Index:          1,
+					MatchedIndexes: []int{},
+					Score:          -6,
+				},
+			},
+		},
+		// operators without a term are matched literally
+		{
+			"^", []string{"a^b", "ab"}, fuzzy.Matches{
+				{
+					Str:            "a^b",
+					Index:          0,
+					MatchedIndexes: []int{1},
+					Score:          -7,
+				},
+			},
+		},
+		// terms are separated by whitespace
+		{
+			"^main !test .go$", []string{"main.go", "main_test.go", "main.rs", "cmd/main.go"}, fuzzy.Matches{
+				{
+					Str:            "main.go",
+					Index:          0,
+					MatchedIndexes: []int{0, 1, 2, 3, 4, 5, 6},
+					Score:          100,
+				},
+			},
+		},
+		{
+			"", []string{"cat"}, nil,
+		},
+	}
+	for _, c := range cases {
+		matches := fuzzy.ParseQuery(c.query).Find(c.data)
+		if diff := pretty.Compare(c.matches, matches); diff != "" {
+			t.Errorf("%v: %v", c.query, diff)
+		}
+	}
+}
+
+func TestParseQueryWithOptions(t *testing.T) {
+	opts := fuzzy.DefaultOptions
+	opts.UnmatchedCharPenalty = 0
+	got, ok := fuzzy.ParseQuery("^mod", fuzzy.WithOptions(opts)).Match("moduleNameResolver.ts")
+	if !ok {
+		t.Fatal("got no match; expected a match")
+	}
+	if got.Score != 30 {
+		t.Errorf("got score %v; expected 30", got.Score)
+	}
+}
+
+func TestFindQueryWithAlternatives(t *testing.T) {
+	cases := []struct {
+		query   string
+		data    []string
+		matches fuzzy.Matches
+	}{
+		{
+			".go$ | .mod$", []string{"main.go", "go.mod", "go.sum"}, fuzzy.Matches{
+				{
+					Str:            "go.mod",
+					Index:          1,
+					MatchedIndexes: []int{2, 3, 4, 5},
+					Score:          73,
+				},
+				{
+					Str:            "main.go",
+					Index:          0,
+					MatchedIndexes: []int{4, 5, 6},
+					Score:          21,
+				},
+			},
+		},
+		// the best alternative is reported, (a = 0 - 5 leading chars, b = 20) - 4 unmatched chars = 11
+		{
+			"ba | ab", []string{"xab_ba"}, fuzzy.Matches{
+				{
+					Str:            "xab_ba",
+					Index:          0,
+					MatchedIndexes: []int{1, 4},
+					Score:          11,
+				},
+			},
+		},
+		// | binds tighter than whitespace
+		{
+			"^core go$ | rb$", []string{"core.go", "lib/core.go", "core.py", "core.rb"}, fuzzy.Matches{
+				{
+					Str:            "core.go",
+					Index:          0,
+					MatchedIndexes: []int{0, 1, 2, 3, 5, 6},
+					Score:          84,
+				},
+				{
+					Str:            "core.rb",
+					Index:          3,
+					MatchedIndexes: []int{0, 1, 2, 3, 5, 6},
+					Score:          84,
+				},
+			},
+		},
+		{
+			"!_test | ^main", []string{"main_test.go", "util_test.go", "util.go"}, fuzzy.Matches{
+				{
+					Str:            "main_test.go",
+					Index:          0,
+					MatchedIndexes: []int{0, 1, 2, 3},
+					Score:          67,
+				},
+				{
+					Str:            "util.go",
+					Index:          2,
+					MatchedIndexes: []int{},
+					Score:          -7,
+				},
+			},
+		},
+		// stray | are ignored
+		{
+			"| main |", []string{"main.go"}, fuzzy.FindQuery("main", []string{"main.go"}),
+		},
+	}
+	for _, c := range cases {
+		matches := fuzzy.FindQuery(c.query, c.data)
+		if diff := pretty.Compare(c.matches, matches); diff != "" {
+			t.Errorf("%v: %v", c.query, diff)
+		}
+	}
+	emps := employees{{name: "Alice"}, {name: "Bob"}, {name: "Allie"}}
+	if diff := pretty.Compare(fuzzy.FindFrom("bob", emps)[:1], fuzzy.FindFromQuery("^bob | ^ali$", emps)); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
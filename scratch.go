@@ -0,0 +1,108 @@
+package fuzzy
+
+// scratch holds buffers that are reused across the strings matched by a single search. It must
+// not be shared between goroutines.
+type scratch struct {
+	// The runes of the string being matched.
+	runes []rune
+	// The byte offset of every rune.
+	offsets []int
+	// The score for matching every rune regardless of the rest of the alignment.
+	bonuses []int
+	table   []int
+	best    []int
+	// The positions of the runes matched with typos, and whether a typo keeps each of them from
+	// earning the adjacency bonus.
+	positions []int
+	breaks    []bool
+	// The indexes of the best term of a group.
+	group []int
+	// The start and end byte offsets of the path segments of the string being matched, and the
+	// table used to align the segments of a term with them.
+	bounds []int
+	scores []int
+	from   []int
+	// The indexes of a segment of a term.
+	segment []int
+	// The string being matched without diacritics and the byte offset of its runes in the
+	// original string.
+	stripped []byte
+	origin   []int
+	// Whether runes, offsets and bonuses hold the string being matched.
+	prepared bool
+	// The number of typos in the last match of a term, or of all the terms matched by matchGroups.
+	typos int
+	// Whether the contributions to the score are recorded in events for Explain.
+	explaining bool
+	events     []event
+}
+
+// reset must be called before matching a new string.
+func (sc *scratch) reset() {
+	sc.prepared = false
+}
+
+// prepare decodes s into runes along with their byte offsets and bonuses unless it was already
+// prepared since the last reset.
+func (sc *scratch) prepare(s string, opts *Options) {
+	if sc.prepared {
+		return
+	}
+	sc.prepared = true
+	sc.runes = sc.runes[:0]
+	sc.offsets = sc.offsets[:0]
+	sc.bonuses = sc.bonuses[:0]
+	for j, r := range s {
+		sc.runes = append(sc.runes, r)
+		sc.offsets = append(sc.offsets, j)
+	}
+	var last rune
+	for j, r := range sc.runes {
+		var next rune
+		if j+1 < len(sc.runes) {
+			next = sc.runes[j+1]
+		}
+		var bonus int
+		if j == 0 {
+			bonus += opts.FirstCharMatchBonus
+		}
+		if isWordBoundary(last, r, next) {
+			bonus += opts.CamelCaseMatchBonus
+		}
+		if j != 0 && opts.separators.contains(last) {
+			bonus += opts.MatchFollowingSeparatorBonus
+		}
+		sc.bonuses = append(sc.bonuses, bonus)
+		last = r
+	}
+}
+
+// score returns the score of matching the runes at positions, which must be in ascending order.
+// If breaks isn't nil, a rune at positions[i] only earns the adjacency bonus if breaks[i] is false.
+func (sc *scratch) score(positions []int, breaks []bool, opts *Options) int {
+	if len(positions) == 0 {
+		return 0
+	}
+	score := sc.leadingPenalty(positions[0], opts)
+	var adjacent int
+	for i, j := range positions {
+		score += sc.bonuses[j]
+		if i > 0 && positions[i-1] == j-1 && (breaks == nil || !breaks[i]) {
+			adjacent++
+		}
+	}
+	return score + adjacentBonusTotal(adjacent, opts)
+}
+
+// leadingPenalty returns the penalty for the runes before the first match at j.
+func (sc *scratch) leadingPenalty(j int, opts *Options) int {
+	return max(sc.offsets[j]*opts.UnmatchedLeadingCharPenalty, opts.MaxUnmatchedLeadingCharPenalty)
+}
+
+// grow returns buf resliced to length n, allocating if its capacity is too small.
+func grow(buf []int, n int) []int {
+	if cap(buf) < n {
+		return make([]int, n)
+	}
+	return buf[:n]
+}
@@ -0,0 +1,145 @@
+package fuzzy
+
+import (
+	"slices"
+	"strings"
+)
+
+// splitSegments splits the fuzzy term t into one fuzzy term per path segment of its runes. It
+// leaves t alone unless it has at least two non-empty segments.
+func splitSegments(t *term) {
+	if t.kind != termFuzzy || t.inverse {
+		return
+	}
+	fields := strings.FieldsFunc(string(t.runes), func(r rune) bool {
+		return strings.ContainsRune(pathSeparators, r)
+	})
+	if len(fields) < 2 {
+		return
+	}
+	t.segments = make([]term, len(fields))
+	for i, field := range fields {
+		t.segments[i] = term{kind: termFuzzy, runes: []rune(field), caseSensitive: t.caseSensitive}
+	}
+}
+
+/*
+matchSegments matches the segments of t against successive segments of the
+path s and returns the score along with indexes extended by the byte index of
+every matched rune. indexes must be empty.
+
+Every segment of t must match a different path segment, in order, although path
+segments may be skipped in between. A segment is scored as if its path segment
+was the whole match string. AdjacentSegmentBonus is applied for every segment
+of t matched in the path segment right after the one of the previous segment.
+The typos of all segments count towards MaxTypos together.
+*/
+func (m *Matcher) matchSegments(t *term, s string, indexes []int, sc *scratch) (int, []int, bool) {
+	sc.bounds = sc.bounds[:0]
+	start := 0
+	for j, r := range s {
+		if strings.ContainsRune(pathSeparators, r) {
+			sc.bounds = append(sc.bounds, start, j)
+			start = j + 1
+		}
+	}
+	sc.bounds = append(sc.bounds, start, len(s))
+	k, n, u := len(t.segments), len(sc.bounds)/2, m.opts.MaxTypos+1
+	if k > n {
+		return 0, indexes, false
+	}
+	mark := len(sc.events)
+	// The segments of s are matched on their own, so the scratch space must be prepared anew.
+	defer sc.reset()
+	segment := func(j int) string { return s[sc.bounds[2*j]:sc.bounds[2*j+1]] }
+
+	// scores[(i*n+j)*u+typos] is the best score for matching t.segments[:i+1] with that many typos
+	// and t.segments[i] in path segment j, and from[(i*n+j)*u+typos] is the path segment of
+	// t.segments[i-1] in that alignment.
+	sc.scores = grow(sc.scores, k*n*u)
+	sc.from = grow(sc.from, k*n*u)
+	cell := func(i, j, typos int) int { return (i*n+j)*u + typos }
+	for i := range t.segments {
+		for j := 0; j < n; j++ {
+			for typos := 0; typos < u; typos++ {
+				sc.scores[cell(i, j, typos)] = unreachable
+			}
+			// Earlier segments of t need path segments of their own, and so do later ones.
+			if j < i || j > n-k+i || i > 0 && !sc.reachable(i-1, j, u) {
+				continue
+			}
+			sc.reset()
+			score, segmentIndexes, ok := m.matchFuzzy(&t.segments[i], segment(j), sc.segment[:0], sc)
+			sc.segment = segmentIndexes
+			if !ok {
+				continue
+			}
+			for typos := sc.typos; typos < u; typos++ {
+				prev, from := 0, -1
+				if i > 0 {
+					prev = unreachable
+					for p := i - 1; p < j; p++ {
+						score := sc.scores[cell(i-1, p, typos-sc.typos)]
+						if p == j-1 && score > unreachable {
+							score += m.opts.AdjacentSegmentBonus
+						}
+						if score > prev {
+							prev, from = score, p
+						}
+					}
+				} else if typos > sc.typos {
+					// The first segment sets the number of typos.
+					continue
+				}
+				if prev > unreachable {
+					sc.scores[cell(i, j, typos)], sc.from[cell(i, j, typos)] = prev+score, from
+				}
+			}
+		}
+	}
+
+	// Only the contributions along the best alignment count, and they are recorded below.
+	sc.events = sc.events[:mark]
+	bestScore, bestJ, bestTypos := unreachable, -1, 0
+	for j := k - 1; j < n; j++ {
+		for typos := 0; typos < u; typos++ {
+			if score := sc.scores[cell(k-1, j, typos)]; score > bestScore {
+				bestScore, bestJ, bestTypos = score, j, typos
+			}
+		}
+	}
+	if bestJ < 0 {
+		return 0, indexes, false
+	}
+	// Match the segments again along the best alignment, last to first, to recover their indexes.
+	for i, j, typos := k-1, bestJ, bestTypos; i >= 0; i-- {
+		sc.reset()
+		segmentMark := len(sc.events)
+		_, sc.segment, _ = m.matchFuzzy(&t.segments[i], segment(j), sc.segment[:0], sc)
+		for _, x := range sc.segment {
+			indexes = append(indexes, sc.bounds[2*j]+x)
+		}
+		sc.shiftEvents(segmentMark, sc.bounds[2*j])
+		from := sc.from[cell(i, j, typos)]
+		if i > 0 && from == j-1 {
+			sc.record(eventSegment, -1, m.opts.AdjacentSegmentBonus)
+		}
+		j, typos = from, typos-sc.typos
+	}
+	// The segments were appended last to first.
+	slices.Sort(indexes)
+	sc.typos = bestTypos
+	return bestScore, indexes, true
+}
+
+// reachable reports whether the table of matchSegments holds an alignment of segment i of a term
+// with a path segment before j, for any of the u numbers of typos.
+func (sc *scratch) reachable(i, j, u int) bool {
+	n := len(sc.bounds) / 2
+	for _, score := range sc.scores[i*n*u : (i*n+j)*u] {
+		if score > unreachable {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package fuzzy_test
+
+import (
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestCompileWithPathSegments(t *testing.T) {
+	data := []string{
+		"scripts/config/maintenance.sh",
+		"src/cmd/main.go",
+		"sc/main.go",
+		"src/internal/cmd/main.go",
+		"src/cmd",
+		`src\cmd\main.go`,
+	}
+	// (s = 10, c = 10, main = 75) + 2 * 10 adjacent segments - 9 unmatched chars = 106
+	want := fuzzy.Matches{
+		{
+			Str:            "src/cmd/main.go",
+			Index:          1,
+			MatchedIndexes: []int{0, 4, 8, 9, 10, 11},
+			Score:          106,
+		},
+		{
+			Str:            `src\cmd\main.go`,
+			Index:          5,
+			MatchedIndexes: []int{0, 4, 8, 9, 10, 11},
+			Score:          106,
+		},
+		{
+			Str:            "scripts/config/maintenance.sh",
+			Index:          0,
+			MatchedIndexes: []int{0, 8, 15, 16, 17, 18},
+			Score:          92,
+		},
+		{
+			Str:            "src/internal/cmd/main.go",
+			Index:          3,
+			MatchedIndexes: []int{0, 13, 17, 18, 19, 20},
+			Score:          87,
+		},
+	}
+	got := fuzzy.Compile("s/c/main", fuzzy.WithPathSegments()).Find(data)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestCompileWithPathSegmentsOptions(t *testing.T) {
+	cases := []struct {
+		pattern string
+		opts    []fuzzy.Option
+		str     string
+		want    []int
+	}{
+		// without path segment mode the slashes are matched like any other rune
+		{"s/c/main", nil, "src/cmd/main.go", []int{0, 3, 4, 7, 8, 9, 10, 11}},
+		// a single segment is matched like any other term
+		{"src/", []fuzzy.Option{fuzzy.WithPathSegments()}, "src/main.go", []int{0, 1, 2, 3}},
+		{`s\m`, []fuzzy.Option{fuzzy.WithPathSegments()}, "src/main.go", []int{0, 4}},
+		{"S/M", []fuzzy.Option{fuzzy.WithPathSegments(), fuzzy.WithCaseMode(fuzzy.CaseSmart)}, "Src/Main.go", []int{0, 4}},
+		{"cafe/m", []fuzzy.Option{fuzzy.WithPathSegments(), fuzzy.WithIgnoreDiacritics()}, "café/main.go", []int{0, 1, 2, 3, 6}},
+		{"s/mian", []fuzzy.Option{fuzzy.WithPathSegments(), fuzzy.WithMaxTypos(1)}, "src/main.go", []int{0, 4, 5, 6, 7}},
+		{"s/c/main", []fuzzy.Option{fuzzy.WithPathSegments(), fuzzy.WithAlgorithm(fuzzy.AlgorithmOptimal)}, "src/cmd/main.go", []int{0, 4, 8, 9, 10, 11}},
+		{"s/c/main go$", []fuzzy.Option{fuzzy.WithPathSegments(), fuzzy.WithPathMode()}, "src/cmd/main.go", []int{0, 4, 8, 9, 10, 11, 13, 14}},
+	}
+	for _, c := range cases {
+		var got []int
+		if match, ok := fuzzy.ParseQuery(c.pattern, c.opts...).Match(c.str); ok {
+			got = match.MatchedIndexes
+		}
+		if diff := pretty.Compare(c.want, got); diff != "" {
+			t.Errorf("%q in %q: %v", c.pattern, c.str, diff)
+		}
+	}
+}
+
+func TestCompileWithPathSegmentsNoMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		str     string
+	}{
+		{"s/c/main", "sc/main.go"},
+		{"s/c/main", "src/cmd"},
+		{"c/s", "src/cmd"},
+		{"s/s", "src/main.go"},
+	}
+	for _, c := range cases {
+		if match, ok := fuzzy.Compile(c.pattern, fuzzy.WithPathSegments()).Match(c.str); ok {
+			t.Errorf("%q matched %q: %v", c.pattern, c.str, match)
+		}
+	}
+}
+
+func TestCompileWithPathSegmentsMaxTypos(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		maxTypos int
+		matches  bool
+		penalty  int
+	}{
+		// the typos of all segments count together
+		{"sxc/cxd/mxin", 1, false, 0},
+		{"sxc/cxd/mxin", 2, false, 0},
+		{"sxc/cxd/mxin", 3, true, -60},
+		{"sxc/cmd/main", 1, true, -20},
+		{"src/cmd/main", 0, true, 0},
+	}
+	for _, c := range cases {
+		m := fuzzy.Compile(c.pattern, fuzzy.WithMaxTypos(c.maxTypos), fuzzy.WithPathSegments())
+		e, ok := m.Explain("src/cmd/main.go")
+		if ok != c.matches {
+			t.Errorf("%q with %v typos: got match %v; expected %v", c.pattern, c.maxTypos, ok, c.matches)
+			continue
+		}
+		if e.TypoPenalty != c.penalty {
+			t.Errorf("%q with %v typos: got typo penalty %v; expected %v", c.pattern, c.maxTypos, e.TypoPenalty, c.penalty)
+		}
+	}
+}
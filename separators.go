@@ -0,0 +1,39 @@
+package fuzzy
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultSeparators are the separators of DefaultOptions.
+const defaultSeparators = "/-_ .\\"
+
+// separatorSet is a set of separator runes with a fast lookup for ASCII runes.
+type separatorSet struct {
+	// A bit for every ASCII rune.
+	ascii [2]uint64
+	// The separators outside of ASCII.
+	other string
+}
+
+func newSeparatorSet(separators string) separatorSet {
+	var set separatorSet
+	var other strings.Builder
+	for _, r := range separators {
+		if r < utf8.RuneSelf {
+			set.ascii[r/64] |= 1 << (r % 64)
+		} else {
+			other.WriteRune(r)
+		}
+	}
+	set.other = other.String()
+	return set
+}
+
+// contains reports whether r is a separator.
+func (set *separatorSet) contains(r rune) bool {
+	if r < utf8.RuneSelf {
+		return r >= 0 && set.ascii[r/64]&(1<<(r%64)) != 0
+	}
+	return set.other != "" && strings.ContainsRune(set.other, r)
+}
@@ -0,0 +1,70 @@
+package fuzzy_test
+
+import (
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestCompileWithSeparators(t *testing.T) {
+	cases := []struct {
+		pattern    string
+		separators string
+		str        string
+		score      int
+	}{
+		// m = -15 leading chars - 14 unmatched chars
+		{"m", fuzzy.DefaultOptions.Separators, "pkg.Type:Method", -29},
+		// m = 20 separator - 15 leading chars - 14 unmatched chars
+		{"m", fuzzy.DefaultOptions.Separators + ":", "pkg.Type:Method", -9},
+		{"m", "::", "pkg.Type:Method", -9},
+		{"m", "#", "README.md#Method", -10},
+		// m = 20 separator - 15 leading bytes - 3 unmatched bytes
+		{"m", "·", "a·m", 2},
+		// m = -15 leading chars - 4 unmatched chars
+		{"m", "/-_ \\", "1.2.m", -19},
+		// (m = 10, m = 0) - 6 unmatched chars
+		{"mm", "", "my_model", 4},
+		// (m = 10, m = 20 separator) + 30 acronym - 6 unmatched chars
+		{"mm", "_", "my_model", 54},
+	}
+	for _, c := range cases {
+		match, ok := fuzzy.Compile(c.pattern, fuzzy.WithSeparators(c.separators)).Match(c.str)
+		if !ok {
+			t.Errorf("%q didn't match %q", c.pattern, c.str)
+			continue
+		}
+		if match.Score != c.score {
+			t.Errorf("%q in %q with separators %q: got score %v; expected %v", c.pattern, c.str, c.separators, match.Score, c.score)
+		}
+	}
+}
+
+func TestFindWithOptionsSeparators(t *testing.T) {
+	opts := fuzzy.DefaultOptions
+	opts.Separators = "/-_ \\"
+	data := []string{"v1.0.3", "v10.2"}
+	// Without "." the 0 of "v1.0.3" no longer earns a separator bonus.
+	// (1 = 20 boundary - 5 leading char, 0 = 5 adjacent) - 3 unmatched chars = 17
+	// (1 = 20 boundary - 5 leading char, 0 = 0) - 4 unmatched chars = 11
+	want := fuzzy.Matches{
+		{
+			Str:            "v10.2",
+			Index:          1,
+			MatchedIndexes: []int{1, 2},
+			Score:          17,
+		},
+		{
+			Str:            "v1.0.3",
+			Index:          0,
+			MatchedIndexes: []int{1, 3},
+			Score:          11,
+		},
+	}
+	got := fuzzy.FindWithOptions("10", data, opts)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
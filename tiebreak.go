@@ -0,0 +1,53 @@
+package fuzzy
+
+import (
+	"cmp"
+	"strings"
+)
+
+// TieBreaker orders matches with equal scores. It returns a negative number if a ranks before b, a
+// positive number if it ranks after b and 0 if it can't tell them apart.
+type TieBreaker func(a, b Match) int
+
+// ByLength ranks shorter strings first.
+func ByLength(a, b Match) int {
+	return cmp.Compare(len(a.Str), len(b.Str))
+}
+
+// ByFirstMatch ranks strings whose first matched character comes earlier first.
+func ByFirstMatch(a, b Match) int {
+	return cmp.Compare(firstMatch(a), firstMatch(b))
+}
+
+// BySpan ranks strings whose matched characters are closer together first.
+func BySpan(a, b Match) int {
+	return cmp.Compare(span(a), span(b))
+}
+
+// ByIndex ranks strings that come earlier in the input first. Matches are always ordered by Index
+// once every other TieBreaker is exhausted.
+func ByIndex(a, b Match) int {
+	return cmp.Compare(a.Index, b.Index)
+}
+
+// ByStr ranks strings in lexicographic order.
+func ByStr(a, b Match) int {
+	return strings.Compare(a.Str, b.Str)
+}
+
+// firstMatch returns the byte index of the first matched character of m, or 0 if there is none.
+func firstMatch(m Match) int {
+	if len(m.MatchedIndexes) == 0 {
+		return 0
+	}
+	return m.MatchedIndexes[0]
+}
+
+// span returns the number of bytes from the first to the last matched character of m, or 0 if there
+// is none.
+func span(m Match) int {
+	if len(m.MatchedIndexes) == 0 {
+		return 0
+	}
+	return m.MatchedIndexes[len(m.MatchedIndexes)-1] - m.MatchedIndexes[0]
+}
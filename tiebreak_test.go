@@ -0,0 +1,72 @@
+package fuzzy_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestCompileWithTieBreakers(t *testing.T) {
+	// Without any bonuses or penalties every match scores 0.
+	data := []string{"b_foo_long.go", "a/foo.go", "xf_o", "foo.go"}
+	cases := []struct {
+		name        string
+		tieBreakers []fuzzy.TieBreaker
+		want        []string
+	}{
+		{"none", nil, []string{"b_foo_long.go", "a/foo.go", "xf_o", "foo.go"}},
+		{"length", []fuzzy.TieBreaker{fuzzy.ByLength}, []string{"xf_o", "foo.go", "a/foo.go", "b_foo_long.go"}},
+		{"first match", []fuzzy.TieBreaker{fuzzy.ByFirstMatch}, []string{"foo.go", "xf_o", "b_foo_long.go", "a/foo.go"}},
+		{"span", []fuzzy.TieBreaker{fuzzy.BySpan}, []string{"b_foo_long.go", "a/foo.go", "foo.go", "xf_o"}},
+		{"index", []fuzzy.TieBreaker{fuzzy.ByIndex}, []string{"b_foo_long.go", "a/foo.go", "xf_o", "foo.go"}},
+		{"str", []fuzzy.TieBreaker{fuzzy.ByStr}, []string{"a/foo.go", "b_foo_long.go", "foo.go", "xf_o"}},
+		{"span and length", []fuzzy.TieBreaker{fuzzy.BySpan, fuzzy.ByLength}, []string{"foo.go", "a/foo.go", "b_foo_long.go", "xf_o"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := fuzzy.Compile("fo", fuzzy.WithOptions(fuzzy.Options{}), fuzzy.WithTieBreakers(c.tieBreakers...))
+			var got []string
+			for _, match := range m.Find(data) {
+				got = append(got, match.Str)
+			}
+			if diff := pretty.Compare(c.want, got); diff != "" {
+				t.Errorf("%v", diff)
+			}
+			got = got[:0]
+			for _, match := range m.FindFromIter(slices.Values(data)) {
+				got = append(got, match.Str)
+			}
+			if diff := pretty.Compare(c.want, got); diff != "" {
+				t.Errorf("FindFromIter: %v", diff)
+			}
+		})
+	}
+}
+
+func TestFindWithOptionsTieBreakers(t *testing.T) {
+	opts := fuzzy.DefaultOptions
+	opts.UnmatchedCharPenalty = 0
+	opts.TieBreakers = []fuzzy.TieBreaker{fuzzy.ByLength}
+	// foo = 30 for both, so the shorter string ranks first
+	want := fuzzy.Matches{
+		{
+			Str:            "foo.go",
+			Index:          1,
+			MatchedIndexes: []int{0, 1, 2},
+			Score:          30,
+		},
+		{
+			Str:            "foo_long_name_test.go",
+			Index:          0,
+			MatchedIndexes: []int{0, 1, 2},
+			Score:          30,
+		},
+	}
+	got := fuzzy.FindWithOptions("foo", []string{"foo_long_name_test.go", "foo.go"}, opts)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
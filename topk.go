@@ -0,0 +1,99 @@
+package fuzzy
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+/*
+FindTopK is an alternative implementation of Find that only returns the k
+best matches. The results are the same as the first k results of Find, but
+only k matches are kept in memory while data is scanned.
+*/
+func FindTopK(pattern string, data []string, k int) Matches {
+	return compile(pattern, DefaultOptions).FindTopK(data, k)
+}
+
+/*
+FindFromTopK is an alternative implementation of FindFrom that only returns
+the k best matches.
+*/
+func FindFromTopK(pattern string, data Source, k int) Matches {
+	return compile(pattern, DefaultOptions).FindFromTopK(data, k)
+}
+
+/*
+FindFromIterTopK is an alternative implementation of FindFromIter that only
+returns the k best matches.
+*/
+func FindFromIterTopK(pattern string, it iter.Seq[string], k int) Matches {
+	return compile(pattern, DefaultOptions).FindFromIterTopK(it, k)
+}
+
+// FindTopK is an alternative implementation of Find that only returns the k best matches.
+func (m *Matcher) FindTopK(data []string, k int) Matches {
+	return m.FindFromTopK(stringSource(data), k)
+}
+
+/*
+FindFromTopK is an alternative implementation of FindFrom that only returns
+the k best matches. With Options.Concurrency, every goroutine keeps the k best
+matches of its part of data.
+*/
+func (m *Matcher) FindFromTopK(data Source, k int) Matches {
+	matches := m.findSharded(data, func(it iter.Seq[string], offset int) Matches {
+		return m.findFromIterTopK(it, offset, k)
+	})
+	// The best matches of every part are sorted already, but not across the parts.
+	m.sort(matches)
+	if len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches
+}
+
+// FindFromIterTopK is an alternative implementation of FindFromIter that only returns the k best
+// matches.
+func (m *Matcher) FindFromIterTopK(it iter.Seq[string], k int) Matches {
+	return m.findFromIterTopK(it, 0, k)
+}
+
+// findFromIterTopK keeps the k best matches of the strings of it. The first string has the index
+// offset.
+func (m *Matcher) findFromIterTopK(it iter.Seq[string], offset, k int) Matches {
+	if k <= 0 {
+		return nil
+	}
+	top := &topK{m: m}
+	for match := range m.all(it, offset) {
+		if len(top.matches) < k {
+			heap.Push(top, match)
+		} else if m.compare(match, top.matches[0]) < 0 {
+			top.matches[0] = match
+			heap.Fix(top, 0)
+		}
+	}
+	if len(top.matches) == 0 {
+		return nil
+	}
+	slices.SortFunc(top.matches, m.compare)
+	return top.matches
+}
+
+// topK is a heap of matches with the worst ranked match at the root.
+type topK struct {
+	m       *Matcher
+	matches Matches
+}
+
+func (t *topK) Len() int           { return len(t.matches) }
+func (t *topK) Swap(i, j int)      { t.matches[i], t.matches[j] = t.matches[j], t.matches[i] }
+func (t *topK) Less(i, j int) bool { return t.m.compare(t.matches[i], t.matches[j]) > 0 }
+func (t *topK) Push(x any)         { t.matches = append(t.matches, x.(Match)) }
+
+func (t *topK) Pop() any {
+	last := t.matches[len(t.matches)-1]
+	t.matches = t.matches[:len(t.matches)-1]
+	return last
+}
@@ -0,0 +1,110 @@
+package fuzzy_test
+
+import (
+	"cmp"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestFindTopK(t *testing.T) {
+	bytes, err := os.ReadFile("testdata/linux_filenames.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filenames := strings.Split(string(bytes), "\n")
+	for _, pattern := range []string{"make", "alsa", "c"} {
+		all := fuzzy.Find(pattern, filenames)
+		for _, k := range []int{1, 50, len(all), len(all) + 10} {
+			want := all[:min(k, len(all))]
+			if diff := pretty.Compare(want, fuzzy.FindTopK(pattern, filenames, k)); diff != "" {
+				t.Errorf("%v with k = %v: %v", pattern, k, diff)
+			}
+		}
+	}
+}
+
+func TestCompileFindTopK(t *testing.T) {
+	bytes, err := os.ReadFile("testdata/linux_filenames.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filenames := strings.Split(string(bytes), "\n")
+	cases := []struct {
+		pattern string
+		opts    []fuzzy.Option
+	}{
+		{"make", []fuzzy.Option{fuzzy.WithPathMode()}},
+		{"alsa", []fuzzy.Option{fuzzy.WithTieBreakers(fuzzy.ByLength)}},
+		{"c", []fuzzy.Option{fuzzy.WithConcurrency(4)}},
+		{"usb", []fuzzy.Option{fuzzy.WithConcurrency(4), fuzzy.WithCaseMode(fuzzy.CaseSensitive)}},
+	}
+	for _, c := range cases {
+		m := fuzzy.Compile(c.pattern, c.opts...)
+		all := m.Find(filenames)
+		for _, k := range []int{1, 50, len(all) + 10} {
+			want := all[:min(k, len(all))]
+			if diff := pretty.Compare(want, m.FindTopK(filenames, k)); diff != "" {
+				t.Errorf("%v with k = %v: %v", c.pattern, k, diff)
+			}
+			if diff := pretty.Compare(want, m.FindFromIterTopK(slices.Values(filenames), k)); diff != "" {
+				t.Errorf("%v with k = %v: %v", c.pattern, k, diff)
+			}
+		}
+	}
+}
+
+func TestCompileFindTopKComparesByIndex(t *testing.T) {
+	// enough strings for several goroutines, which must rank them by their index in data
+	data := make([]string, 5000)
+	for i := range data {
+		data[i] = "abc"
+	}
+	distance := func(m fuzzy.Match) int { return (m.Index - 3000) * (m.Index - 3000) }
+	compare := func(a, b fuzzy.Match) int { return cmp.Compare(distance(a), distance(b)) }
+	for _, concurrency := range []int{1, 4} {
+		m := fuzzy.Compile("abc", fuzzy.WithCompare(compare), fuzzy.WithConcurrency(concurrency))
+		want := m.Find(data)[:3]
+		if diff := pretty.Compare(want, m.FindTopK(data, 3)); diff != "" {
+			t.Errorf("with concurrency %v: %v", concurrency, diff)
+		}
+	}
+}
+
+func TestFindTopKWithoutResults(t *testing.T) {
+	data := []string{"moduleNameResolver.ts", "my name is_Ramsey"}
+	if got := fuzzy.FindTopK("mnr", data, 0); got != nil {
+		t.Errorf("got %v; expected no Matches for k = 0", got)
+	}
+	if got := fuzzy.FindTopK("xyz", data, 10); got != nil {
+		t.Errorf("got %v; expected no Matches", got)
+	}
+}
+
+func TestFindFromTopK(t *testing.T) {
+	emps := employees{{name: "Alice"}, {name: "Bob"}, {name: "Allie"}, {name: "Al"}}
+	want := fuzzy.FindFrom("al", emps)[:2]
+	if diff := pretty.Compare(want, fuzzy.FindFromTopK("al", emps, 2)); diff != "" {
+		t.Errorf("%v", diff)
+	}
+	if diff := pretty.Compare(want, fuzzy.FindFromIterTopK("al", emps.Values(), 2)); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func BenchmarkFindTopK(b *testing.B) {
+	bytes, err := os.ReadFile("testdata/linux_filenames.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	filenames := strings.Split(string(bytes), "\n")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fuzzy.FindTopK("c", filenames, 50)
+	}
+}
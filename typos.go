@@ -0,0 +1,140 @@
+package fuzzy
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+/*
+matchTypos matches the runes of t against s allowing up to Options.MaxTypos typos.
+It returns the score along with indexes extended by the byte index of every
+rune that actually matched, and reports false if s needs more typos or no rune
+matched at all. indexes must be empty.
+
+The table holds the cost of matching the first i runes of t within the first j
+runes of s. The cost counts typos first and pattern runes that didn't match
+second, so transposed runes are preferred over missing ones. Among the
+alignments with the lowest cost the one matching runes as early as possible is
+scored like any other match, plus Options.TypoPenalty for every typo.
+*/
+func matchTypos(s string, t *term, indexes []int, opts *Options, sc *scratch) (int, []int, bool) {
+	if t.missingRunes(s) > opts.MaxTypos {
+		return 0, indexes, false
+	}
+	sc.prepare(s, opts)
+	p, c := t.runes, sc.runes
+	m, n := len(p), len(c)
+	typo := m + 1
+	sc.table = grow(sc.table, (m+1)*(n+1))
+	cell := func(i, j int) *int { return &sc.table[i*(n+1)+j] }
+	for i := 0; i <= m; i++ {
+		for j := 0; j <= n; j++ {
+			// runes of s before the first pattern rune are skipped for free
+			var cost int
+			if i > 0 {
+				// the pattern rune is missing
+				cost = *cell(i-1, j) + typo + 1
+				if j > 0 {
+					// the rune of s is skipped, matched or substituted
+					cost = min(cost, *cell(i, j-1))
+					if t.equal(p[i-1], c[j-1]) {
+						cost = min(cost, *cell(i-1, j-1))
+					} else {
+						cost = min(cost, *cell(i-1, j-1)+typo+1)
+					}
+				}
+				if t.transposedAt(c, i, j) {
+					cost = min(cost, *cell(i-2, j-2)+typo)
+				}
+			}
+			*cell(i, j) = cost
+		}
+	}
+	typos := *cell(m, n) / typo
+	if typos > opts.MaxTypos {
+		return 0, indexes, false
+	}
+
+	// Walk the table backwards. Skipping runes of s first moves the matched runes as far to the
+	// front as possible. Runes matched on either side of a typo aren't adjacent, and neither are
+	// transposed runes.
+	sc.positions, sc.breaks = sc.positions[:0], sc.breaks[:0]
+	brk := func() {
+		if len(sc.breaks) > 0 {
+			sc.breaks[len(sc.breaks)-1] = true
+		}
+	}
+	for i, j := m, n; i > 0; {
+		cost := *cell(i, j)
+		switch {
+		case j > 0 && *cell(i, j-1) == cost:
+			j--
+		case j > 0 && t.equal(p[i-1], c[j-1]) && *cell(i-1, j-1) == cost:
+			sc.positions = append(sc.positions, j-1)
+			sc.breaks = append(sc.breaks, false)
+			i, j = i-1, j-1
+		case t.transposedAt(c, i, j) && *cell(i-2, j-2)+typo == cost:
+			brk()
+			sc.positions = append(sc.positions, j-1, j-2)
+			sc.breaks = append(sc.breaks, true, true)
+			i, j = i-2, j-2
+		case j > 0 && *cell(i-1, j-1)+typo+1 == cost:
+			brk()
+			i, j = i-1, j-1
+		default:
+			brk()
+			i--
+		}
+	}
+	if len(sc.positions) == 0 {
+		return 0, indexes, false
+	}
+	// positions and breaks were collected back to front
+	slices.Reverse(sc.positions)
+	slices.Reverse(sc.breaks)
+	for _, j := range sc.positions {
+		indexes = append(indexes, sc.offsets[j])
+	}
+	sc.typos = typos
+	sc.recordIndexes(indexes, sc.breaks, opts)
+	sc.record(eventTypos, -1, typos*opts.TypoPenalty)
+	return sc.score(sc.positions, sc.breaks, opts) + typos*opts.TypoPenalty, indexes, true
+}
+
+// transposedAt reports whether the runes i-2 and i-1 of t match the runes j-1 and j-2 of s.
+func (t *term) transposedAt(s []rune, i, j int) bool {
+	return i >= 2 && j >= 2 && t.equal(t.runes[i-2], s[j-1]) && t.equal(t.runes[i-1], s[j-2])
+}
+
+// missingRunes returns the number of runes of t that don't occur in s, ignoring their order. Each
+// of them needs a typo of its own, so it is a cheap lower bound for the number of typos. It only
+// considers ASCII and returns 0 if s isn't ASCII.
+func (t *term) missingRunes(s string) int {
+	var counts [utf8.RuneSelf]int
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return 0
+		}
+		counts[t.foldASCII(rune(s[i]))]++
+	}
+	var missing int
+	for _, r := range t.runes {
+		if r >= utf8.RuneSelf {
+			continue
+		}
+		if r = t.foldASCII(r); counts[r] > 0 {
+			counts[r]--
+		} else {
+			missing++
+		}
+	}
+	return missing
+}
+
+// foldASCII maps the ASCII rune r to lower case unless t is case-sensitive.
+func (t *term) foldASCII(r rune) rune {
+	if !t.caseSensitive && 'A' <= r && r <= 'Z' {
+		return r + 'a' - 'A'
+	}
+	return r
+}
@@ -0,0 +1,108 @@
+package fuzzy_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestCompileWithMaxTypos(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		maxTypos int
+		str      string
+		matches  bool
+		indexes  []int
+		score    int
+	}{
+		// two transpositions, (h = 10, r = 5) - 2 typos - 3 unmatched chars = -28
+		// transposed runes and runes after a typo aren't adjacent
+		{"hnalder", 1, "handler.go", false, nil, 0},
+		{"hnalder", 2, "handler.go", true, []int{0, 1, 2, 3, 4, 5, 6}, -28},
+		// (h = 10, ler = 65) - 1 typo - 3 unmatched chars = 52
+		{"hnadler", 1, "handler.go", true, []int{0, 1, 2, 3, 4, 5, 6}, 52},
+		// substitutions aren't highlighted
+		{"hamdler", 1, "handler.go", true, []int{0, 1, 3, 4, 5, 6}, 186},
+		// missing runes aren't highlighted either
+		{"handlerx", 1, "handler.go", true, []int{0, 1, 2, 3, 4, 5, 6}, 1807},
+		{"ab", 1, "ba", true, []int{0, 1}, -10},
+		// at least one rune must match
+		{"ab", 2, "xy", false, nil, 0},
+		{"abc", 2, "x", false, nil, 0},
+	}
+	for _, c := range cases {
+		got, ok := fuzzy.Compile(c.pattern, fuzzy.WithMaxTypos(c.maxTypos)).Match(c.str)
+		if ok != c.matches {
+			t.Errorf("%q in %q with %v typos: got match %v; expected %v", c.pattern, c.str, c.maxTypos, ok, c.matches)
+			continue
+		}
+		if diff := pretty.Compare(c.indexes, got.MatchedIndexes); diff != "" {
+			t.Errorf("%q in %q: %v", c.pattern, c.str, diff)
+		}
+		if got.Score != c.score {
+			t.Errorf("%q in %q: got score %v; expected %v", c.pattern, c.str, got.Score, c.score)
+		}
+	}
+}
+
+func TestCompileWithMaxTyposPrefersExactMatches(t *testing.T) {
+	data := []string{"moduleNameResolver.ts", "my name is_Ramsey", "mnt", "mr"}
+	got := fuzzy.Compile("mnr", fuzzy.WithMaxTypos(1)).Find(data)
+	want := append(fuzzy.Find("mnr", data),
+		fuzzy.Match{Str: "mnt", Index: 2, MatchedIndexes: []int{0, 1}, Score: -6},
+		fuzzy.Match{Str: "mr", Index: 3, MatchedIndexes: []int{0, 1}, Score: -10},
+	)
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("%v", diff)
+	}
+}
+
+func TestCompileWithMaxTyposCountsAllTerms(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		maxTypos int
+		matches  bool
+	}{
+		{"sxc cmd main", 1, true},
+		{"sxc cxd mxin", 1, false},
+		{"sxc cxd mxin", 3, true},
+		// the alternative without typos is preferred
+		{"sxc | src cxd", 1, true},
+	}
+	for _, c := range cases {
+		m := fuzzy.ParseQuery(c.pattern, fuzzy.WithMaxTypos(c.maxTypos))
+		if _, ok := m.Match("src/cmd/main.go"); ok != c.matches {
+			t.Errorf("%q with %v typos: got match %v; expected %v", c.pattern, c.maxTypos, ok, c.matches)
+		}
+	}
+}
+
+func TestCompileWithTypoPenalty(t *testing.T) {
+	opts := fuzzy.DefaultOptions
+	opts.MaxTypos = 1
+	opts.TypoPenalty = -100
+	got, ok := fuzzy.Compile("ab", fuzzy.WithOptions(opts)).Match("ba")
+	if !ok {
+		t.Fatal("got no match; expected a match")
+	}
+	if got.Score != -90 {
+		t.Errorf("got score %v; expected -90", got.Score)
+	}
+}
+
+func BenchmarkFindWithTypos(b *testing.B) {
+	bytes, err := os.ReadFile("testdata/linux_filenames.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	filenames := strings.Split(string(bytes), "\n")
+	m := fuzzy.Compile("alsa", fuzzy.WithMaxTypos(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Find(filenames)
+	}
+}